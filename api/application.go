@@ -1,13 +1,23 @@
 package api
 
 import (
+	"cosmos-server/pkg/model"
 	"regexp"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 var applicationNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
 
+var gitProviderRule = validation.In(
+	model.GitProviderGithub,
+	model.GitProviderGitlab,
+	model.GitProviderBitbucket,
+	model.GitProviderBitbucketServer,
+	model.GitProviderLocal,
+).Error("provider must be one of github, gitlab, bitbucket, bitbucket-server or local")
+
 type CreateApplicationRequest struct {
 	Name                  string                 `json:"name"`
 	Description           string                 `json:"description"`
@@ -29,6 +39,9 @@ type MonitoringInformation struct {
 	OpenAPIPath    string `json:"openAPIPath"`
 	HasOpenClient  bool   `json:"hasOpenClient"`
 	OpenClientPath string `json:"openClientPath"`
+	// SyncInterval overrides the sentinel interval for the application, in seconds
+	SyncInterval *int  `json:"syncInterval,omitempty"`
+	SyncEnabled  *bool `json:"syncEnabled,omitempty"`
 }
 
 func (r *CreateApplicationRequest) Validate() error {
@@ -46,7 +59,7 @@ func (r *CreateApplicationRequest) Validate() error {
 			validation.By(func(value any) error {
 				if gi, ok := value.(*GitInformation); ok && gi != nil {
 					return validation.ValidateStruct(gi,
-						validation.Field(&gi.Provider, validation.Required),
+						validation.Field(&gi.Provider, validation.Required, gitProviderRule),
 						validation.Field(&gi.RepositoryOwner, validation.Required),
 						validation.Field(&gi.RepositoryName, validation.Required),
 						validation.Field(&gi.RepositoryBranch, validation.Required),
@@ -62,6 +75,7 @@ func (r *CreateApplicationRequest) Validate() error {
 					return validation.ValidateStruct(mi,
 						validation.Field(&mi.OpenAPIPath, validation.When(mi.HasOpenAPI, validation.Required)),
 						validation.Field(&mi.OpenClientPath, validation.When(mi.HasOpenClient, validation.Required)),
+						validation.Field(&mi.SyncInterval, validation.Min(1).Error("sync interval must be a positive number of seconds")),
 					)
 				}
 				return nil
@@ -81,6 +95,18 @@ type Application struct {
 	GitInformation        *GitInformation        `json:"gitInformation,omitempty"`
 	MonitoringInformation *MonitoringInformation `json:"monitoringInformation,omitempty"`
 	Token                 *Token                 `json:"token,omitempty"`
+	SyncStatus            *SyncStatus            `json:"syncStatus,omitempty"`
+}
+
+type SyncStatus struct {
+	LastAttemptAt                *time.Time `json:"lastAttemptAt,omitempty"`
+	LastSuccessAt                *time.Time `json:"lastSuccessAt,omitempty"`
+	LastError                    string     `json:"lastError,omitempty"`
+	LastErrorCategory            string     `json:"lastErrorCategory,omitempty"`
+	ConsecutiveFailures          int        `json:"consecutiveFailures"`
+	ConsecutivePermanentFailures int        `json:"consecutivePermanentFailures"`
+	Quarantined                  bool       `json:"quarantined"`
+	QuarantinedAt                *time.Time `json:"quarantinedAt,omitempty"`
 }
 
 type GetApplicationResponse struct {
@@ -105,12 +131,11 @@ func (r *UpdateApplicationRequest) Validate() error {
 		validation.Field(&r.Name, validation.When(r.Name != nil, validation.Length(1, 100))),
 		validation.Field(&r.Description, validation.When(r.Description != nil, validation.Length(0, 500))),
 		validation.Field(&r.Team, validation.When(r.Team != nil, validation.Length(0, 100))),
-		validation.Field(&r.GitInformation, validation.When(r.MonitoringInformation != nil, validation.Required.Error("git information is required when monitoring is provided"))),
 		validation.Field(&r.GitInformation, validation.When(r.GitInformation != nil,
 			validation.By(func(value any) error {
 				if gi, ok := value.(*GitInformation); ok && gi != nil {
 					return validation.ValidateStruct(gi,
-						validation.Field(&gi.Provider, validation.Required),
+						validation.Field(&gi.Provider, validation.Required, gitProviderRule),
 						validation.Field(&gi.RepositoryOwner, validation.Required),
 						validation.Field(&gi.RepositoryName, validation.Required),
 						validation.Field(&gi.RepositoryBranch, validation.Required),
@@ -126,6 +151,7 @@ func (r *UpdateApplicationRequest) Validate() error {
 					return validation.ValidateStruct(mi,
 						validation.Field(&mi.OpenAPIPath, validation.When(mi.HasOpenAPI, validation.Required)),
 						validation.Field(&mi.OpenClientPath, validation.When(mi.HasOpenClient, validation.Required)),
+						validation.Field(&mi.SyncInterval, validation.Min(1).Error("sync interval must be a positive number of seconds")),
 					)
 				}
 				return nil
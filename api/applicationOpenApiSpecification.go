@@ -1,6 +1,111 @@
 package api
 
+import (
+	"cosmos-server/pkg/model"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// GetApplicationOpenAPISpecificationResponse holds OpenAPISpec as an OpenAPI 3.0 document, OpenAPIVersion is the version
+// the application published, it is omitted for the specifications stored before the version was recorded
 type GetApplicationOpenAPISpecificationResponse struct {
 	ApplicationName string `json:"applicationName"`
+	OpenAPIVersion  string `json:"openAPIVersion,omitempty"`
 	OpenAPISpec     string `json:"openAPISpec"`
 }
+
+type GetApplicationOpenAPISpecificationVersionsResponse struct {
+	ApplicationName string                                    `json:"applicationName"`
+	Versions        []*ApplicationOpenAPISpecificationVersion `json:"versions"`
+	Page            int                                       `json:"page"`
+	PageSize        int                                       `json:"pageSize"`
+	Total           int64                                     `json:"total"`
+}
+
+type GetApplicationOpenAPISpecificationVersionResponse struct {
+	ApplicationName string                                  `json:"applicationName"`
+	Version         *ApplicationOpenAPISpecificationVersion `json:"version"`
+	OpenAPISpec     string                                  `json:"openAPISpec"`
+}
+
+type ApplicationOpenAPISpecificationVersion struct {
+	ID             uint       `json:"id"`
+	OpenAPISha     string     `json:"openAPISha"`
+	OpenAPIVersion string     `json:"openAPIVersion,omitempty"`
+	Commit         *GitCommit `json:"commit,omitempty"`
+	IngestedAt     time.Time  `json:"ingestedAt"`
+}
+
+type GitCommit struct {
+	Sha         string     `json:"sha"`
+	Message     string     `json:"message,omitempty"`
+	AuthorName  string     `json:"authorName,omitempty"`
+	AuthorEmail string     `json:"authorEmail,omitempty"`
+	Date        *time.Time `json:"date,omitempty"`
+}
+
+// UpdateOpenAPIRetentionRequest replaces the retention of an application, an omitted limit keeps every version
+type UpdateOpenAPIRetentionRequest struct {
+	MaxVersions *int `json:"maxVersions"`
+	MaxAgeDays  *int `json:"maxAgeDays"`
+}
+
+func (r *UpdateOpenAPIRetentionRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.MaxVersions, validation.Min(1).Error("max versions must be a positive number")),
+		validation.Field(&r.MaxAgeDays, validation.Min(1).Error("max age must be a positive number of days")),
+	)
+}
+
+type GetOpenAPIRetentionResponse struct {
+	MaxVersions *int `json:"maxVersions"`
+	MaxAgeDays  *int `json:"maxAgeDays"`
+}
+
+type GetOpenAPISpecificationDiffResponse struct {
+	ApplicationName string                         `json:"applicationName"`
+	Base            *OpenAPISpecificationReference `json:"base"`
+	Revision        *OpenAPISpecificationReference `json:"revision"`
+	Changes         []*OpenAPIDiffChange           `json:"changes"`
+	Format          string                         `json:"format,omitempty"`
+	Rendered        string                         `json:"rendered,omitempty"`
+}
+
+type OpenAPISpecificationReference struct {
+	VersionID *uint  `json:"versionId,omitempty"`
+	GitRef    string `json:"gitRef,omitempty"`
+}
+
+type OpenAPIDiffChange struct {
+	ID                string   `json:"id"`
+	Level             string   `json:"level"`
+	Operation         string   `json:"operation,omitempty"`
+	Path              string   `json:"path,omitempty"`
+	Message           string   `json:"message"`
+	AffectsConsumers  bool     `json:"affectsConsumers"`
+	AffectedConsumers []string `json:"affectedConsumers,omitempty"`
+}
+
+// CheckOpenAPISpecificationRequest holds a candidate OpenAPI document, as JSON or YAML, to check before it is merged
+type CheckOpenAPISpecificationRequest struct {
+	OpenAPISpec string `json:"openAPISpec"`
+	// FailOn is the least severe level of a change to a consumed operation that fails the check, error by default
+	FailOn string `json:"failOn,omitempty"`
+}
+
+func (r *CheckOpenAPISpecificationRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.OpenAPISpec, validation.Required),
+		validation.Field(&r.FailOn, validation.In(model.OpenAPIChangeLevelError, model.OpenAPIChangeLevelWarning, model.OpenAPIChangeLevelInfo).Error("failOn must be one of error, warning or info")),
+	)
+}
+
+type CheckOpenAPISpecificationResponse struct {
+	ApplicationName        string               `json:"applicationName"`
+	Passed                 bool                 `json:"passed"`
+	FailOn                 string               `json:"failOn"`
+	HasStoredSpecification bool                 `json:"hasStoredSpecification"`
+	Changes                []*OpenAPIDiffChange `json:"changes"`
+	IgnoredChangesCount    int                  `json:"ignoredChangesCount"`
+}
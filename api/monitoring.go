@@ -1,5 +1,11 @@
 package api
 
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 type GetApplicationsInteractionsResponse struct {
 	ApplicationsInvolved map[string]ApplicationInformation `json:"applicationsInvolved"`
 	Dependencies         []ApplicationDependency           `json:"dependencies"`
@@ -22,12 +28,15 @@ type Endpoints map[string]EndpointMethods
 type EndpointMethods map[string]EndpointDetails
 
 type EndpointDetails struct {
-	Reasons []string `json:"reasons"`
+	Reasons    []string `json:"reasons"`
+	Parameters []string `json:"parameters,omitempty"`
+	Fields     []string `json:"fields,omitempty"`
 }
 
 type GetCompleteApplicationMonitoringResponse struct {
 	Application       Application             `json:"application"`
 	OpenAPISpec       string                  `json:"openAPISpec"`
+	OpenAPIVersion    string                  `json:"openAPIVersion,omitempty"`
 	Dependencies      []ApplicationDependency `json:"dependencies"`
 	ConsumedEndpoints ConsumedEndpoints       `json:"consumedEndpoints"`
 }
@@ -39,3 +48,149 @@ type ConsumedEndpointMethods map[string]ConsumedEndpointDetails
 type ConsumedEndpointDetails struct {
 	Consumers []string `json:"consumers"`
 }
+
+// PreviewApplicationSyncRequest holds the application changes to preview, the stored application is used when empty
+type PreviewApplicationSyncRequest struct {
+	GitInformation        *GitInformation        `json:"gitInformation,omitempty"`
+	MonitoringInformation *MonitoringInformation `json:"monitoringInformation,omitempty"`
+	TokenName             *string                `json:"tokenName,omitempty"`
+}
+
+func (r *PreviewApplicationSyncRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.GitInformation, validation.When(r.GitInformation != nil,
+			validation.By(func(value any) error {
+				if gi, ok := value.(*GitInformation); ok && gi != nil {
+					return validation.ValidateStruct(gi,
+						validation.Field(&gi.Provider, validation.Required, gitProviderRule),
+						validation.Field(&gi.RepositoryOwner, validation.Required),
+						validation.Field(&gi.RepositoryName, validation.Required),
+						validation.Field(&gi.RepositoryBranch, validation.Required),
+					)
+				}
+				return nil
+			}),
+		)),
+		validation.Field(&r.MonitoringInformation, validation.When(r.MonitoringInformation != nil,
+			validation.By(func(value any) error {
+				if mi, ok := value.(*MonitoringInformation); ok && mi != nil {
+					return validation.ValidateStruct(mi,
+						validation.Field(&mi.OpenAPIPath, validation.When(mi.HasOpenAPI, validation.Required)),
+						validation.Field(&mi.OpenClientPath, validation.When(mi.HasOpenClient, validation.Required)),
+					)
+				}
+				return nil
+			}),
+		)),
+	)
+}
+
+type PreviewApplicationSyncResponse struct {
+	ApplicationName string                   `json:"applicationName"`
+	CommitSha       string                   `json:"commitSha,omitempty"`
+	Dependencies    *DependenciesSyncPreview `json:"dependencies,omitempty"`
+	OpenAPI         *OpenAPISyncPreview      `json:"openAPI,omitempty"`
+}
+
+type DependenciesSyncPreview struct {
+	DependenciesSha      string                         `json:"dependenciesSha,omitempty"`
+	DependenciesToUpsert []ApplicationDependency        `json:"dependenciesToUpsert"`
+	PendingDependencies  []PendingApplicationDependency `json:"pendingDependencies"`
+	DependenciesToDelete []ApplicationDependency        `json:"dependenciesToDelete"`
+	Error                string                         `json:"error,omitempty"`
+}
+
+type PendingApplicationDependency struct {
+	Consumer  string    `json:"consumer"`
+	Provider  string    `json:"provider"`
+	Reasons   []string  `json:"reasons"`
+	Endpoints Endpoints `json:"endpoints"`
+}
+
+type OpenAPISyncPreview struct {
+	OpenAPISha             string           `json:"openAPISha,omitempty"`
+	HasStoredSpecification bool             `json:"hasStoredSpecification"`
+	Changes                []*OpenAPIChange `json:"changes"`
+	Error                  string           `json:"error,omitempty"`
+}
+
+type OpenAPIChange struct {
+	ID        string `json:"id"`
+	Level     string `json:"level"`
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Message   string `json:"message"`
+}
+
+// ValidateOpenClientSpecificationRequest holds a candidate openclient.json to validate before it is merged
+type ValidateOpenClientSpecificationRequest struct {
+	OpenClientSpec string `json:"openClientSpec"`
+}
+
+func (r *ValidateOpenClientSpecificationRequest) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.OpenClientSpec, validation.Required),
+	)
+}
+
+type ValidateOpenClientSpecificationResponse struct {
+	ApplicationName string                            `json:"applicationName"`
+	Valid           bool                              `json:"valid"`
+	Errors          []string                          `json:"errors"`
+	Dependencies    []*OpenClientDependencyValidation `json:"dependencies"`
+}
+
+type OpenClientDependencyValidation struct {
+	Name     string   `json:"name"`
+	Pending  bool     `json:"pending"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+type GetContractDriftsResponse struct {
+	Drifts []ContractDrift `json:"drifts"`
+}
+
+type ContractDrift struct {
+	Consumer        string    `json:"consumer"`
+	Provider        string    `json:"provider"`
+	Path            string    `json:"path"`
+	Method          string    `json:"method"`
+	FirstDetectedAt time.Time `json:"firstDetectedAt"`
+}
+
+type GetProviderOperationsResponse struct {
+	Provider   string              `json:"provider"`
+	Operations []ProviderOperation `json:"operations"`
+}
+
+type ProviderOperation struct {
+	Method    string              `json:"method"`
+	Path      string              `json:"path"`
+	Consumers []OperationConsumer `json:"consumers"`
+}
+
+type OperationConsumer struct {
+	Name    string   `json:"name"`
+	Reasons []string `json:"reasons"`
+}
+
+type GetUnusedOperationsResponse struct {
+	ApplicationName            string            `json:"applicationName"`
+	HasStoredSpecification     bool              `json:"hasStoredSpecification"`
+	UnusedOperations           []UnusedOperation `json:"unusedOperations"`
+	DeprecatedUnusedOperations []UnusedOperation `json:"deprecatedUnusedOperations"`
+}
+
+type GetTeamUnusedOperationsResponse struct {
+	Team         string                        `json:"team"`
+	Applications []GetUnusedOperationsResponse `json:"applications"`
+}
+
+type UnusedOperation struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Deprecated  bool   `json:"deprecated"`
+}
@@ -1,5 +1,7 @@
 package api
 
+import "time"
+
 type UpdateSentinelSettingsRequest struct {
 	Interval *int  `json:"interval"`
 	Enabled  *bool `json:"enabled"`
@@ -9,3 +11,95 @@ type GetSentinelSettingsResponse struct {
 	Interval int  `json:"interval"`
 	Enabled  bool `json:"enabled"`
 }
+
+type GetSentinelRunsResponse struct {
+	Runs     []*SentinelRunSummary `json:"runs"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"pageSize"`
+	Total    int64                 `json:"total"`
+}
+
+type SentinelRunSummary struct {
+	ID                      uint       `json:"id"`
+	Trigger                 string     `json:"trigger"`
+	StartedAt               time.Time  `json:"startedAt"`
+	FinishedAt              *time.Time `json:"finishedAt"`
+	ApplicationsCount       int        `json:"applicationsCount"`
+	FailedApplicationsCount int        `json:"failedApplicationsCount"`
+}
+
+type GetSentinelRunResponse struct {
+	Run *SentinelRun `json:"run"`
+}
+
+type SentinelRun struct {
+	ID           uint                      `json:"id"`
+	Trigger      string                    `json:"trigger"`
+	StartedAt    time.Time                 `json:"startedAt"`
+	FinishedAt   *time.Time                `json:"finishedAt"`
+	Applications []*SentinelRunApplication `json:"applications"`
+}
+
+type SentinelRunApplication struct {
+	ApplicationName string `json:"applicationName"`
+	Status          string `json:"status"`
+	DependenciesSha string `json:"dependenciesSha,omitempty"`
+	OpenAPISha      string `json:"openAPISha,omitempty"`
+	DurationMs      int64  `json:"durationMs"`
+	Error           string `json:"error,omitempty"`
+}
+
+type GetGitRateLimitsResponse struct {
+	RateLimits []*GitRateLimit `json:"rateLimits"`
+}
+
+type GitRateLimit struct {
+	Provider  string    `json:"provider"`
+	Token     string    `json:"token"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	ResetAt   time.Time `json:"resetAt"`
+	Paused    bool      `json:"paused"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SyncApplicationResponse struct {
+	Job *SyncJob `json:"job"`
+}
+
+type SyncAllApplicationsResponse struct {
+	Jobs []*SyncJob `json:"jobs"`
+}
+
+type GetSyncJobResponse struct {
+	Job *SyncJob `json:"job"`
+}
+
+type SyncJob struct {
+	ID              uint       `json:"id"`
+	ApplicationName string     `json:"applicationName"`
+	RunID           *uint      `json:"runId,omitempty"`
+	Trigger         string     `json:"trigger"`
+	Status          string     `json:"status"`
+	Attempts        int        `json:"attempts"`
+	AvailableAt     time.Time  `json:"availableAt"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+type GetSentinelWorkersResponse struct {
+	QueueDepth int64             `json:"queueDepth"`
+	Workers    []*SentinelWorker `json:"workers"`
+}
+
+type SentinelWorker struct {
+	ID              int        `json:"id"`
+	Status          string     `json:"status"`
+	ApplicationName string     `json:"applicationName,omitempty"`
+	JobID           *uint      `json:"jobId,omitempty"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	Deadline        *time.Time `json:"deadline,omitempty"`
+	RunningForMs    int64      `json:"runningForMs"`
+}
@@ -0,0 +1,5 @@
+package api
+
+type GithubWebhookResponse struct {
+	QueuedApplications []string `json:"queuedApplications"`
+}
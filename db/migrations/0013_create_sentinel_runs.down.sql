@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sentinel_run_applications;
+DROP TABLE IF EXISTS sentinel_runs;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS sentinel_runs (
+    id SERIAL PRIMARY KEY,
+    trigger VARCHAR(50) NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX sentinel_runs_started_at_idx ON sentinel_runs(started_at);
+
+CREATE TABLE IF NOT EXISTS sentinel_run_applications (
+    id SERIAL PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES sentinel_runs(id) ON DELETE CASCADE,
+    application_id INTEGER REFERENCES applications(id) ON DELETE SET NULL,
+    application_name VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    dependencies_sha VARCHAR(64),
+    open_api_sha VARCHAR(64),
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX sentinel_run_applications_run_id_idx ON sentinel_run_applications(run_id);
+CREATE INDEX sentinel_run_applications_application_id_idx ON sentinel_run_applications(application_id);
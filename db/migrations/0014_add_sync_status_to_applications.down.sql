@@ -0,0 +1,6 @@
+ALTER TABLE applications
+DROP COLUMN IF EXISTS consecutive_sync_failures,
+DROP COLUMN IF EXISTS last_sync_error_category,
+DROP COLUMN IF EXISTS last_sync_error,
+DROP COLUMN IF EXISTS last_sync_success_at,
+DROP COLUMN IF EXISTS last_sync_attempt_at;
@@ -0,0 +1,6 @@
+ALTER TABLE applications
+ADD COLUMN last_sync_attempt_at TIMESTAMP,
+ADD COLUMN last_sync_success_at TIMESTAMP,
+ADD COLUMN last_sync_error TEXT NOT NULL DEFAULT '',
+ADD COLUMN last_sync_error_category VARCHAR(50) NOT NULL DEFAULT '',
+ADD COLUMN consecutive_sync_failures INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE applications
+DROP COLUMN IF EXISTS commit_sha;
@@ -0,0 +1,2 @@
+ALTER TABLE applications
+ADD COLUMN commit_sha VARCHAR(64) NOT NULL DEFAULT '';
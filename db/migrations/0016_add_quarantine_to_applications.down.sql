@@ -0,0 +1,3 @@
+ALTER TABLE applications
+DROP COLUMN IF EXISTS quarantined_at,
+DROP COLUMN IF EXISTS consecutive_permanent_failures;
@@ -0,0 +1,3 @@
+ALTER TABLE applications
+ADD COLUMN consecutive_permanent_failures INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN quarantined_at TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE applications
+DROP COLUMN IF EXISTS sync_disabled,
+DROP COLUMN IF EXISTS sync_interval;
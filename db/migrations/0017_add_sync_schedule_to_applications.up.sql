@@ -0,0 +1,3 @@
+ALTER TABLE applications
+ADD COLUMN sync_interval INTEGER,
+ADD COLUMN sync_disabled BOOLEAN NOT NULL DEFAULT FALSE;
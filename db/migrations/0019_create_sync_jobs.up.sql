@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS sync_jobs (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    run_id INTEGER REFERENCES sentinel_runs(id) ON DELETE SET NULL,
+    trigger VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    available_at TIMESTAMP NOT NULL,
+    claimed_by VARCHAR(255),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- An application has at most one job waiting in the queue
+CREATE UNIQUE INDEX sync_jobs_queued_application_id_idx ON sync_jobs(application_id) WHERE status = 'queued';
+CREATE INDEX sync_jobs_status_available_at_idx ON sync_jobs(status, available_at);
+CREATE INDEX sync_jobs_run_id_idx ON sync_jobs(run_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS application_open_api_retentions;
+DROP TABLE IF EXISTS application_open_api_versions;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS application_open_api_versions (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    open_api JSONB NOT NULL,
+    open_api_sha VARCHAR(64) NOT NULL DEFAULT '',
+    commit_sha VARCHAR(64) NOT NULL DEFAULT '',
+    commit_message TEXT NOT NULL DEFAULT '',
+    commit_author_name VARCHAR(255) NOT NULL DEFAULT '',
+    commit_author_email VARCHAR(255) NOT NULL DEFAULT '',
+    committed_at TIMESTAMP,
+    ingested_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX application_open_api_versions_application_id_ingested_at_idx ON application_open_api_versions(application_id, ingested_at);
+
+-- NULL limits keep every version
+CREATE TABLE IF NOT EXISTS application_open_api_retentions (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    max_versions INTEGER,
+    max_age_days INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (application_id)
+);
+
+-- The specifications stored so far become the first version of their application, the commit they came from is unknown
+INSERT INTO application_open_api_versions (application_id, open_api, open_api_sha, ingested_at)
+SELECT application_open_apis.application_id, application_open_apis.open_api, COALESCE(applications.open_api_sha, ''), application_open_apis.updated_at
+FROM application_open_apis
+JOIN applications ON applications.id = application_open_apis.application_id
+WHERE application_open_apis.open_api IS NOT NULL;
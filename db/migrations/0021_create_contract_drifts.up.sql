@@ -0,0 +1,11 @@
+-- An endpoint a consumer declares that the stored specification of the provider does not have
+CREATE TABLE IF NOT EXISTS contract_drifts (
+    id SERIAL PRIMARY KEY,
+    dependency_id INTEGER NOT NULL REFERENCES application_dependencies(id) ON DELETE CASCADE,
+    path VARCHAR(2048) NOT NULL,
+    method VARCHAR(16) NOT NULL,
+    first_detected_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (dependency_id, path, method)
+);
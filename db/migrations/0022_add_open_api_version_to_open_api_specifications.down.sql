@@ -0,0 +1,5 @@
+ALTER TABLE application_open_api_versions
+DROP COLUMN IF EXISTS open_api_version;
+
+ALTER TABLE application_open_apis
+DROP COLUMN IF EXISTS open_api_version;
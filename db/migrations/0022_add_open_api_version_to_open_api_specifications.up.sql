@@ -0,0 +1,9 @@
+-- The version the published document declares, the stored document is always an OpenAPI 3.0 one
+ALTER TABLE application_open_apis
+ADD COLUMN open_api_version VARCHAR(16) NOT NULL DEFAULT '';
+
+ALTER TABLE application_open_api_versions
+ADD COLUMN open_api_version VARCHAR(16) NOT NULL DEFAULT '';
+
+-- The specifications stored so far are not backfilled, their stored document does not tell a converted Swagger 2
+-- document from an OpenAPI 3.0 one, an empty version reads as unknown
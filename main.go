@@ -63,8 +63,5 @@ func main() {
 		return
 	}
 
-	// I wait a little to let the workers finish
-	time.Sleep(2 * time.Second)
-
 	fmt.Println("Server exited gracefully")
 }
@@ -16,15 +16,17 @@ import (
 	"cosmos-server/pkg/services/team"
 	"cosmos-server/pkg/services/token"
 	"cosmos-server/pkg/services/user"
+	"cosmos-server/pkg/services/webhook"
 	"cosmos-server/pkg/storage"
 	"fmt"
 	"net/http"
 )
 
 type App struct {
-	config *c.Config
-	routes *routes.HTTPRoutes
-	server *http.Server
+	config       *c.Config
+	routes       *routes.HTTPRoutes
+	server       *http.Server
+	sentinelDone chan struct{}
 }
 
 func NewApp(config *c.Config) (*App, error) {
@@ -48,12 +50,25 @@ func NewApp(config *c.Config) (*App, error) {
 	authService := auth.NewAuthService(config.AuthConfig, storageService, auth.NewTranslator(), logger)
 	userService := user.NewUserService(storageService, user.NewTranslator(), logger)
 	teamService := team.NewTeamService(storageService, team.NewTranslator())
-	applicationService := application.NewApplicationService(storageService, application.NewTranslator(), logger)
-	monitoringService := monitoring.NewMonitoringService(storageService, monitoring.NewGithubService(), monitoring.NewOpenApiService(), mailService, config.SentinelConfig.MaxIntervalSeconds, config.SentinelConfig.MinIntervalSeconds, encryptor, monitoring.NewTranslator(), logger)
+	applicationService := application.NewApplicationService(storageService, config.SentinelConfig.MinIntervalSeconds, application.NewTranslator(), logger)
+	gitServices := map[string]monitoring.GitService{
+		model.GitProviderGithub:          monitoring.NewGithubService(),
+		model.GitProviderGitlab:          monitoring.NewGitlabService(config.GitConfig.GitlabBaseURL, config.GitConfig.GitlabToken),
+		model.GitProviderBitbucket:       monitoring.NewBitbucketCloudService(config.GitConfig.BitbucketBaseURL),
+		model.GitProviderBitbucketServer: monitoring.NewBitbucketServerService(config.GitConfig.BitbucketServerBaseURL),
+		model.GitProviderLocal:           monitoring.NewLocalGitService(config.GitConfig.LocalRepositoriesPath),
+	}
+	syncPolicy := monitoring.SyncPolicy{
+		MaxAttempts:         config.SentinelConfig.RetryMaxAttempts,
+		BaseDelay:           config.SentinelConfig.RetryBaseDelayDuration,
+		QuarantineThreshold: config.SentinelConfig.QuarantineThreshold,
+	}
+	monitoringService := monitoring.NewMonitoringService(storageService, gitServices, monitoring.NewOpenApiService(), mailService, config.SentinelConfig.MaxIntervalSeconds, config.SentinelConfig.MinIntervalSeconds, syncPolicy, encryptor, monitoring.NewTranslator(), logger)
 	tokenService := token.NewTokenService(encryptor, storageService, token.NewTranslator(), logger)
 	groupService := group.NewGroupService(storageService, group.NewTranslator(), logger)
+	webhookService := webhook.NewWebhookService(config.WebhookConfig, applicationService, monitoringService, logger)
 
-	httpRoutes := routes.NewHTTPRoutes(authService, userService, teamService, applicationService, monitoringService, tokenService, groupService, logger)
+	httpRoutes := routes.NewHTTPRoutes(authService, userService, teamService, applicationService, monitoringService, tokenService, groupService, webhookService, logger)
 
 	return &App{
 		config: config,
@@ -123,19 +138,37 @@ func (app *App) RunServer() error {
 func (app *App) StartSentinel(ctx context.Context) {
 	newSettingsChannel := make(chan model.SentinelSettings, 3) // I think 1 would suffice, but just in case
 
-	sentinel := sentinel.NewSentinel(app.routes.Logger, app.routes.ApplicationService, app.routes.MonitoringService, newSettingsChannel, *app.config.SentinelConfig.SentinelWorkers)
+	sentinel := sentinel.NewSentinel(app.routes.Logger, app.routes.ApplicationService, app.routes.MonitoringService, newSettingsChannel, *app.config.SentinelConfig.SentinelWorkers, app.config.SentinelConfig.LeaseDurationDuration, app.config.SentinelConfig.SyncTimeoutDuration)
 	fallbackSettings := &model.SentinelSettings{
 		Interval: app.config.SentinelConfig.DefaultIntervalSeconds,
 		Enabled:  app.config.SentinelConfig.DefaultEnabled,
 	}
-	go sentinel.Start(ctx, fallbackSettings)
+	app.sentinelDone = make(chan struct{})
+	go func() {
+		sentinel.Start(ctx, fallbackSettings)
+		close(app.sentinelDone)
+	}()
 
 	app.routes.MonitoringService.StoreSentinelChannel(newSettingsChannel)
+	app.routes.MonitoringService.StoreSentinelWorkersProvider(sentinel.Workers)
 }
 
+// Shutdown stops the server and waits for the sentinel workers, whose context must be cancelled already, to hand
+// their jobs back to the queue
 func (app *App) Shutdown(ctx context.Context) error {
 	if app.server != nil {
-		return app.server.Shutdown(ctx)
+		if err := app.server.Shutdown(ctx); err != nil {
+			return err
+		}
 	}
+
+	if app.sentinelDone != nil {
+		select {
+		case <-app.sentinelDone:
+		case <-ctx.Done():
+			return fmt.Errorf("sentinel did not stop in time: %v", ctx.Err())
+		}
+	}
+
 	return nil
 }
@@ -5,6 +5,14 @@ import (
 	"time"
 )
 
+const (
+	defaultSentinelRetryMaxAttempts    = 3
+	defaultSentinelRetryBaseDelay      = "1s"
+	defaultSentinelQuarantineThreshold = 5
+	defaultSentinelLeaseDuration       = "30s"
+	defaultSentinelSyncTimeout         = "5m"
+)
+
 type Config struct {
 	ServerConfig   `mapstructure:"server"`
 	StorageConfig  `mapstructure:"storage"`
@@ -14,6 +22,8 @@ type Config struct {
 	LogConfig      `mapstructure:"log"`
 	SentinelConfig `mapstructure:"sentinel"`
 	MailConfig     `mapstructure:"mail"`
+	WebhookConfig  `mapstructure:"webhook"`
+	GitConfig      `mapstructure:"git"`
 }
 
 type ServerConfig struct {
@@ -44,7 +54,27 @@ type SentinelConfig struct {
 	DefaultIntervalSeconds int
 	MinIntervalSeconds     int
 	MaxIntervalSeconds     int
-	SentinelWorkers        *int `mapstructure:"sentinel_workers"`
+	SentinelWorkers        *int   `mapstructure:"sentinel_workers"`
+	RetryMaxAttempts       int    `mapstructure:"retry_max_attempts"`
+	RetryBaseDelay         string `mapstructure:"retry_base_delay"`
+	QuarantineThreshold    int    `mapstructure:"quarantine_threshold"`
+	LeaseDuration          string `mapstructure:"lease_duration"`
+	SyncTimeout            string `mapstructure:"sync_timeout"`
+	RetryBaseDelayDuration time.Duration
+	LeaseDurationDuration  time.Duration
+	SyncTimeoutDuration    time.Duration
+}
+
+type WebhookConfig struct {
+	GithubSecret string `mapstructure:"github_secret"`
+}
+
+type GitConfig struct {
+	GitlabBaseURL          string `mapstructure:"gitlab_base_url"`
+	GitlabToken            string `mapstructure:"gitlab_token"`
+	BitbucketBaseURL       string `mapstructure:"bitbucket_base_url"`
+	BitbucketServerBaseURL string `mapstructure:"bitbucket_server_base_url"`
+	LocalRepositoriesPath  string `mapstructure:"local_repositories_path"`
 }
 
 type DefaultAdmin struct {
@@ -103,6 +133,57 @@ func (sc *SentinelConfig) ValidateAndSetDefaults() error {
 		return fmt.Errorf("default_interval must be between min_interval and max_interval")
 	}
 
+	if sc.RetryMaxAttempts == 0 {
+		sc.RetryMaxAttempts = defaultSentinelRetryMaxAttempts
+	}
+
+	if sc.RetryMaxAttempts < 0 {
+		return fmt.Errorf("retry_max_attempts must be greater than 0")
+	}
+
+	if sc.RetryBaseDelay == "" {
+		sc.RetryBaseDelay = defaultSentinelRetryBaseDelay
+	}
+
+	sc.RetryBaseDelayDuration, err = time.ParseDuration(sc.RetryBaseDelay)
+	if err != nil {
+		return err
+	}
+
+	if sc.QuarantineThreshold == 0 {
+		sc.QuarantineThreshold = defaultSentinelQuarantineThreshold
+	}
+
+	if sc.QuarantineThreshold < 0 {
+		return fmt.Errorf("quarantine_threshold must be greater than 0")
+	}
+
+	if sc.LeaseDuration == "" {
+		sc.LeaseDuration = defaultSentinelLeaseDuration
+	}
+
+	sc.LeaseDurationDuration, err = time.ParseDuration(sc.LeaseDuration)
+	if err != nil {
+		return err
+	}
+
+	if sc.LeaseDurationDuration <= 0 {
+		return fmt.Errorf("lease_duration must be greater than 0")
+	}
+
+	if sc.SyncTimeout == "" {
+		sc.SyncTimeout = defaultSentinelSyncTimeout
+	}
+
+	sc.SyncTimeoutDuration, err = time.ParseDuration(sc.SyncTimeout)
+	if err != nil {
+		return err
+	}
+
+	if sc.SyncTimeoutDuration <= 0 {
+		return fmt.Errorf("sync_timeout must be greater than 0")
+	}
+
 	return nil
 }
 
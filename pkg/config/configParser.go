@@ -35,11 +35,22 @@ func readConfig(path string) (*Config, error) {
 		{"sentinel.min_interval", "SENTINEL_MIN_INTERVAL"},
 		{"sentinel.max_interval", "SENTINEL_MAX_INTERVAL"},
 		{"sentinel.sentinel_workers", "SENTINEL_WORKERS"},
+		{"sentinel.retry_max_attempts", "SENTINEL_RETRY_MAX_ATTEMPTS"},
+		{"sentinel.retry_base_delay", "SENTINEL_RETRY_BASE_DELAY"},
+		{"sentinel.quarantine_threshold", "SENTINEL_QUARANTINE_THRESHOLD"},
+		{"sentinel.lease_duration", "SENTINEL_LEASE_DURATION"},
+		{"sentinel.sync_timeout", "SENTINEL_SYNC_TIMEOUT"},
 		{"token.encryption_key", "TOKEN_ENCRYPTION_KEY"},
 		{"mail.smtp_host", "MAIL_SMTP_HOST"},
 		{"mail.smtp_port", "MAIL_SMTP_PORT"},
 		{"mail.sender_email", "MAIL_SENDER_EMAIL"},
 		{"mail.smtp_password", "MAIL_SMTP_PASSWORD"},
+		{"webhook.github_secret", "GITHUB_WEBHOOK_SECRET"},
+		{"git.gitlab_base_url", "GITLAB_BASE_URL"},
+		{"git.gitlab_token", "GITLAB_TOKEN"},
+		{"git.bitbucket_base_url", "BITBUCKET_BASE_URL"},
+		{"git.bitbucket_server_base_url", "BITBUCKET_SERVER_BASE_URL"},
+		{"git.local_repositories_path", "LOCAL_REPOSITORIES_PATH"},
 	})
 	if err != nil {
 		return nil, err
@@ -2,5 +2,28 @@ package model
 
 type AppEndpointDependencies struct {
 	Application *Application
-	Endpoints   map[string]bool
+	// Endpoints holds the details of the operations the application declares by their key, see OperationKey
+	Endpoints map[string]EndpointDetails
+}
+
+// UsesOperation reports whether the application declares the operation of the specification of matcher in its
+// openclient.json
+func (d *AppEndpointDependencies) UsesOperation(matcher *OperationMatcher, method, path string) bool {
+	_, ok := d.GetDeclaredOperation(matcher, method, path)
+	return ok
+}
+
+// GetDeclaredOperation returns the details the application declares for the operation of the specification of matcher
+func (d *AppEndpointDependencies) GetDeclaredOperation(matcher *OperationMatcher, method, path string) (EndpointDetails, bool) {
+	if method == "" || path == "" {
+		return EndpointDetails{}, false
+	}
+
+	for _, key := range matcher.OperationKeys(method, path) {
+		if details, ok := d.Endpoints[key]; ok {
+			return details, true
+		}
+	}
+
+	return EndpointDetails{}, false
 }
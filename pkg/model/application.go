@@ -1,5 +1,13 @@
 package model
 
+const (
+	GitProviderGithub          = "github"
+	GitProviderGitlab          = "gitlab"
+	GitProviderBitbucket       = "bitbucket"
+	GitProviderBitbucketServer = "bitbucket-server"
+	GitProviderLocal           = "local"
+)
+
 type Application struct {
 	Name                  string
 	Description           string
@@ -7,6 +15,7 @@ type Application struct {
 	GitInformation        *GitInformation
 	MonitoringInformation *MonitoringInformation
 	Token                 *Token
+	SyncStatus            *SyncStatus
 }
 
 type GitInformation struct {
@@ -19,10 +28,14 @@ type GitInformation struct {
 type MonitoringInformation struct {
 	DependenciesSha string
 	OpenAPISha      string
+	CommitSha       string
 	HasOpenApi      bool
 	OpenApiPath     string
 	HasOpenClient   bool
 	OpenClientPath  string
+	// SyncInterval overrides the sentinel interval for the application, in seconds. The sentinel interval is used when nil.
+	SyncInterval *int
+	SyncEnabled  bool
 }
 
 type ApplicationUpdate struct {
@@ -31,5 +44,7 @@ type ApplicationUpdate struct {
 	Team                  *string
 	GitInformation        *GitInformation
 	MonitoringInformation *MonitoringInformation
-	TokenName             *string
+	// SyncEnabled keeps the current value when nil, the SyncEnabled of MonitoringInformation is not used by updates
+	SyncEnabled *bool
+	TokenName   *string
 }
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"slices"
+	"strings"
+)
+
 type ApplicationDependency struct {
 	Consumer  *Application
 	Provider  *Application
@@ -12,7 +17,9 @@ type Endpoints map[string]EndpointMethods
 type EndpointMethods map[string]EndpointDetails
 
 type EndpointDetails struct {
-	Reasons []string `json:"reasons,omitempty"`
+	Reasons    []string `json:"reasons,omitempty"`
+	Parameters []string `json:"parameters,omitempty"`
+	Fields     []string `json:"fields,omitempty"`
 }
 
 type PendingApplicationDependency struct {
@@ -21,3 +28,83 @@ type PendingApplicationDependency struct {
 	Reasons      []string
 	Endpoints    Endpoints
 }
+
+// DeclaresFields is false when the consumer depends on the whole operation, it declared no parameter nor field
+func (d EndpointDetails) DeclaresFields() bool {
+	return len(d.Parameters) > 0 || len(d.Fields) > 0
+}
+
+// UsesParameter reports whether the consumer declares the parameter, header names being case-insensitive the
+// comparison is too
+func (d EndpointDetails) UsesParameter(name string) bool {
+	for _, parameter := range d.Parameters {
+		if strings.EqualFold(strings.TrimSpace(parameter), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UsesField reports whether a property, as oasdiff names it like data/items/name, is a declared field, one of their
+// parents or one of their children. The array items and schema compositions oasdiff adds to the names are skipped
+func (d EndpointDetails) UsesField(property string) bool {
+	propertySegments := splitFieldPath(property)
+	if len(propertySegments) == 0 {
+		return false
+	}
+
+	for _, field := range d.Fields {
+		if fieldSegments := splitFieldPath(field); len(fieldSegments) > 0 && fieldPathsOverlap(fieldSegments, propertySegments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Merge gives the details of an operation declared under several paths matching the same operation, like /users/{id}
+// and /users/{userId}/, depending on the whole operation wins
+func (d EndpointDetails) Merge(other EndpointDetails) EndpointDetails {
+	merged := EndpointDetails{Reasons: append(slices.Clone(d.Reasons), other.Reasons...)}
+	if !d.DeclaresFields() || !other.DeclaresFields() {
+		return merged
+	}
+
+	merged.Parameters = append(slices.Clone(d.Parameters), other.Parameters...)
+	merged.Fields = append(slices.Clone(d.Fields), other.Fields...)
+	return merged
+}
+
+func splitFieldPath(field string) []string {
+	segments := make([]string, 0)
+	for _, segment := range strings.FieldsFunc(field, func(r rune) bool { return r == '.' || r == '/' }) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+func fieldPathsOverlap(fieldSegments, propertySegments []string) bool {
+	i, j := 0, 0
+	for i < len(fieldSegments) && j < len(propertySegments) {
+		switch {
+		case fieldSegments[i] == propertySegments[j]:
+			i++
+			j++
+		case isSchemaStructureSegment(propertySegments[j]):
+			j++
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isSchemaStructureSegment(segment string) bool {
+	return segment == "items" || segment == "additionalProperties" ||
+		strings.HasPrefix(segment, "allOf[") || strings.HasPrefix(segment, "anyOf[") || strings.HasPrefix(segment, "oneOf[")
+}
@@ -1,8 +1,41 @@
 package model
 
-import "github.com/getkin/kin-openapi/openapi3"
+import (
+	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ApplicationOpenAPISpecification holds OpenAPISpec as an OpenAPI 3.0 document, Swagger 2 and OpenAPI 3.1 documents are
+// converted. OpenAPIVersion is the version the application published.
 type ApplicationOpenAPISpecification struct {
-	Application *Application
-	OpenAPISpec *openapi3.T
+	Application    *Application
+	OpenAPISpec    *openapi3.T
+	OpenAPIVersion string
+}
+
+// ApplicationOpenAPIVersion is a specification an application published at some point. Commit is nil for the versions
+// stored before their commit was recorded, and OpenAPISpec is only loaded when a single version is fetched.
+type ApplicationOpenAPIVersion struct {
+	ID             uint
+	Application    *Application
+	OpenAPISha     string
+	OpenAPIVersion string
+	Commit         *GitCommit
+	IngestedAt     time.Time
+	OpenAPISpec    *openapi3.T
+}
+
+type ApplicationOpenAPIVersionsPage struct {
+	Versions []*ApplicationOpenAPIVersion
+	Page     int
+	PageSize int
+	Total    int64
+}
+
+// OpenAPIRetention limits the versions kept for an application, a nil limit keeps every version. The latest version is
+// always kept.
+type OpenAPIRetention struct {
+	MaxVersions *int
+	MaxAgeDays  *int
 }
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// ContractDrift is an endpoint a consumer declares in its openclient.json that the stored OpenAPI specification of the
+// provider does not have
+type ContractDrift struct {
+	Consumer        *Application
+	Provider        *Application
+	Path            string
+	Method          string
+	FirstDetectedAt time.Time
+}
@@ -0,0 +1,19 @@
+package model
+
+// EndpointConsumerFilter narrows the operations of a provider, Path may be a path template or a concrete path
+type EndpointConsumerFilter struct {
+	Method string
+	Path   string
+}
+
+// ProviderOperation is an operation of a provider along with the applications declaring it in their openclient.json
+type ProviderOperation struct {
+	Method    string
+	Path      string
+	Consumers []*OperationConsumer
+}
+
+type OperationConsumer struct {
+	Name    string
+	Reasons []string
+}
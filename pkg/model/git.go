@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type FileMetadata struct {
 	Name       string
 	Path       string
@@ -14,3 +16,11 @@ type FileContent struct {
 	Metadata FileMetadata
 	Content  string
 }
+
+type GitCommit struct {
+	Sha         string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	Date        *time.Time
+}
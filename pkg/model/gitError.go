@@ -0,0 +1,22 @@
+package model
+
+// GitStatusError is returned by the git services when the provider answers a request with an error status
+type GitStatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func NewGitStatusError(statusCode int, err error) *GitStatusError {
+	return &GitStatusError{
+		StatusCode: statusCode,
+		Err:        err,
+	}
+}
+
+func (e *GitStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *GitStatusError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type GitRateLimit struct {
+	Provider  string
+	Token     string
+	Limit     int
+	Remaining int
+	Used      int
+	ResetAt   time.Time
+	UpdatedAt time.Time
+}
+
+func (l *GitRateLimit) Exhausted(now time.Time) bool {
+	return l.Remaining <= 0 && now.Before(l.ResetAt)
+}
+
+type RateLimitError struct {
+	Provider string
+	ResetAt  time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded, it resets at %s", e.Provider, e.ResetAt.Format(time.RFC3339))
+}
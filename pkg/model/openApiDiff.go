@@ -0,0 +1,55 @@
+package model
+
+const (
+	OpenAPIDiffFormatHTML     = "html"
+	OpenAPIDiffFormatMarkdown = "markdown"
+)
+
+// OpenAPISpecificationReference points either at a stored version of the specification of an application or at a git
+// ref (branch, tag or commit) of its repository
+type OpenAPISpecificationReference struct {
+	VersionID *uint
+	GitRef    string
+}
+
+// OpenAPIDiff holds the changes between two specifications of an application. Rendered is only set when a format was
+// requested.
+type OpenAPIDiff struct {
+	Application *Application
+	Base        *OpenAPISpecificationReference
+	Revision    *OpenAPISpecificationReference
+	Changes     []*OpenAPIChange
+	Format      string
+	Rendered    string
+}
+
+const (
+	OpenAPIChangeLevelError   = "error"
+	OpenAPIChangeLevelWarning = "warning"
+	OpenAPIChangeLevelInfo    = "info"
+)
+
+// OpenAPIChangeLevelSeverity orders the levels of the changes, unknown levels are the least severe
+func OpenAPIChangeLevelSeverity(level string) int {
+	switch level {
+	case OpenAPIChangeLevelError:
+		return 3
+	case OpenAPIChangeLevelWarning:
+		return 2
+	case OpenAPIChangeLevelInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// OpenAPICheck is the verdict on a candidate specification of an application. Changes only holds the changes to
+// operations consumers declare, it fails when one of them is at least as severe as FailOn.
+type OpenAPICheck struct {
+	Application            *Application
+	HasStoredSpecification bool
+	FailOn                 string
+	Passed                 bool
+	Changes                []*OpenAPIChange
+	IgnoredChangesCount    int
+}
@@ -19,6 +19,10 @@ type EndpointMethodsSpecification map[string]EndpointSpecification
 
 type EndpointSpecification struct {
 	Reasons []string `json:"reasons"`
+	// Parameters and Fields are optional, when declared only the changes touching them are notified. Nested fields
+	// are separated by dots or slashes, like data.items.name
+	Parameters []string `json:"parameters,omitempty"`
+	Fields     []string `json:"fields,omitempty"`
 }
 
 var (
@@ -46,14 +50,26 @@ func (spec *OpenClientSpecification) Validate() error {
 				return fmt.Errorf("invalid path '%s' for dependency %s: path must start with '/' and contain valid URL characters", path, depName)
 			}
 
-			for method := range methods {
+			for method, endpoint := range methods {
 				if method == "" {
 					return fmt.Errorf("endpoint method cannot be empty for dependency %s", depName)
 				}
 
-				if !isValidHTTPMethod(method) {
+				if !IsValidHTTPMethod(method) {
 					return fmt.Errorf("invalid HTTP method '%s' for dependency %s: must be one of GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS, TRACE", method, depName)
 				}
+
+				for _, parameter := range endpoint.Parameters {
+					if strings.TrimSpace(parameter) == "" {
+						return fmt.Errorf("parameter name cannot be empty for endpoint %s %s of dependency %s", method, path, depName)
+					}
+				}
+
+				for _, field := range endpoint.Fields {
+					if len(splitFieldPath(field)) == 0 {
+						return fmt.Errorf("field cannot be empty for endpoint %s %s of dependency %s", method, path, depName)
+					}
+				}
 			}
 		}
 	}
@@ -83,6 +99,6 @@ func isValidPath(path string) bool {
 	return true
 }
 
-func isValidHTTPMethod(method string) bool {
+func IsValidHTTPMethod(method string) bool {
 	return validHTTPMethods[strings.ToUpper(method)]
 }
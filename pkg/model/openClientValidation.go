@@ -0,0 +1,18 @@
+package model
+
+// OpenClientValidation is the outcome of checking a candidate openclient.json of an application, Errors holds the
+// problems of the document itself, the problems of each dependency are kept with the dependency
+type OpenClientValidation struct {
+	Application  *Application
+	Valid        bool
+	Errors       []string
+	Dependencies []*OpenClientDependencyValidation
+}
+
+type OpenClientDependencyValidation struct {
+	Name string
+	// Pending is true when no application is registered with the name of the dependency yet
+	Pending  bool
+	Errors   []string
+	Warnings []string
+}
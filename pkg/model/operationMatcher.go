@@ -0,0 +1,152 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var pathParameterRegex = regexp.MustCompile(`\{[^}/]*\}`)
+
+// OperationMatcher matches the operations consumers declare in their openclient.json against the operations of the
+// specification of a provider. Path parameters match whatever their name, trailing slashes are ignored and a declared
+// path may include the base path of one of the servers of the provider.
+type OperationMatcher struct {
+	spec      *openapi3.T
+	basePaths []string
+	// paths maps the normalized declared paths matching a path of the specification to that path
+	paths map[string]string
+}
+
+// NewOperationMatcher accepts a nil specification, its matcher only normalizes the paths
+func NewOperationMatcher(spec *openapi3.T) *OperationMatcher {
+	matcher := &OperationMatcher{
+		spec:  spec,
+		paths: make(map[string]string),
+	}
+
+	if spec == nil {
+		return matcher
+	}
+
+	for _, server := range spec.Servers {
+		basePath, err := server.BasePath()
+		if err != nil {
+			continue
+		}
+
+		if basePath = NormalizePathTemplate(basePath); basePath != "/" {
+			matcher.basePaths = append(matcher.basePaths, basePath)
+		}
+	}
+
+	if spec.Paths != nil {
+		for path := range spec.Paths.Map() {
+			for _, key := range matcher.PathKeys(path) {
+				matcher.paths[key] = path
+			}
+		}
+	}
+
+	return matcher
+}
+
+// NormalizePathTemplate gives the same path to the paths only differing by the names of their parameters, a trailing
+// slash or their case
+func NormalizePathTemplate(path string) string {
+	path = "/" + strings.Trim(strings.ToLower(path), "/")
+	return pathParameterRegex.ReplaceAllString(path, "{}")
+}
+
+// OperationKey identifies an operation declared by a consumer, declared operations are matched on their key
+func OperationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + NormalizePathTemplate(path)
+}
+
+// PathKeys returns the normalized paths a consumer may declare for a path of the specification
+func (m *OperationMatcher) PathKeys(path string) []string {
+	normalizedPath := NormalizePathTemplate(path)
+
+	keys := make([]string, 0, len(m.basePaths)+1)
+	keys = append(keys, normalizedPath)
+	for _, basePath := range m.basePaths {
+		keys = append(keys, NormalizePathTemplate(basePath+normalizedPath))
+	}
+
+	return keys
+}
+
+// OperationKeys returns the keys of the declared operations matching an operation of the specification
+func (m *OperationMatcher) OperationKeys(method, path string) []string {
+	pathKeys := m.PathKeys(path)
+
+	keys := make([]string, 0, len(pathKeys))
+	for _, pathKey := range pathKeys {
+		keys = append(keys, strings.ToUpper(method)+" "+pathKey)
+	}
+
+	return keys
+}
+
+// MatchesPath tells whether a declared path matches a path template or a concrete path, their segments being equal or a
+// path parameter on either side. Either path may include the base path of one of the servers of the provider
+func (m *OperationMatcher) MatchesPath(declaredPath, path string) bool {
+	for _, declaredKey := range m.withoutBasePaths(declaredPath) {
+		for _, key := range m.withoutBasePaths(path) {
+			if segmentsMatch(strings.Split(declaredKey, "/"), strings.Split(key, "/")) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// withoutBasePaths returns the normalized path along with the path without each base path it starts with
+func (m *OperationMatcher) withoutBasePaths(path string) []string {
+	normalizedPath := NormalizePathTemplate(path)
+
+	keys := []string{normalizedPath}
+	for _, basePath := range m.basePaths {
+		if strings.HasPrefix(normalizedPath, basePath+"/") {
+			keys = append(keys, strings.TrimPrefix(normalizedPath, basePath))
+		}
+	}
+
+	return keys
+}
+
+func segmentsMatch(segments, otherSegments []string) bool {
+	if len(segments) != len(otherSegments) {
+		return false
+	}
+
+	for i := range segments {
+		if segments[i] != otherSegments[i] && segments[i] != "{}" && otherSegments[i] != "{}" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FindPath returns the path of the specification a declared path matches, with its item, or an empty path and nil
+func (m *OperationMatcher) FindPath(declaredPath string) (string, *openapi3.PathItem) {
+	path, ok := m.paths[NormalizePathTemplate(declaredPath)]
+	if !ok {
+		return "", nil
+	}
+
+	return path, m.spec.Paths.Value(path)
+}
+
+// FindOperation returns the operation of the specification a declared operation matches, or nil
+func (m *OperationMatcher) FindOperation(declaredMethod, declaredPath string) *openapi3.Operation {
+	_, pathItem := m.FindPath(declaredPath)
+	if pathItem == nil {
+		return nil
+	}
+
+	return pathItem.GetOperation(strings.ToUpper(declaredMethod))
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	SentinelRunTriggerScheduled = "scheduled"
+	SentinelRunTriggerWebhook   = "webhook"
+	SentinelRunTriggerManual    = "manual"
+
+	SyncStatusSucceeded = "succeeded"
+	SyncStatusFailed    = "failed"
+)
+
+type SentinelRun struct {
+	ID           uint
+	Trigger      string
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+	Applications []*SentinelRunApplication
+}
+
+type SentinelRunApplication struct {
+	ApplicationName string
+	Status          string
+	DependenciesSha string
+	OpenAPISha      string
+	Duration        time.Duration
+	Error           string
+}
+
+type SentinelRunsPage struct {
+	Runs     []*SentinelRun
+	Page     int
+	PageSize int
+	Total    int64
+}
+
+type ApplicationSyncResult struct {
+	Application     *Application
+	StartedAt       time.Time
+	Duration        time.Duration
+	CommitSha       string
+	DependenciesSha string
+	OpenAPISha      string
+	DependenciesErr error
+	OpenAPIErr      error
+}
+
+func (r *ApplicationSyncResult) Failed() bool {
+	return r.DependenciesErr != nil || r.OpenAPIErr != nil
+}
+
+func (r *ApplicationSyncResult) ErrorMessage() string {
+	errorMessages := make([]string, 0, 2)
+	if r.DependenciesErr != nil {
+		errorMessages = append(errorMessages, r.DependenciesErr.Error())
+	}
+	// Both errors are the same when the repository could not be read at all
+	if r.OpenAPIErr != nil && r.OpenAPIErr != r.DependenciesErr {
+		errorMessages = append(errorMessages, r.OpenAPIErr.Error())
+	}
+	return strings.Join(errorMessages, "; ")
+}
+
+func (r *ApplicationSyncResult) ErrorCategory() string {
+	if r.DependenciesErr != nil {
+		return SyncErrorCategory(r.DependenciesErr)
+	}
+	return SyncErrorCategory(r.OpenAPIErr)
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	SentinelWorkerStatusIdle    = "idle"
+	SentinelWorkerStatusRunning = "running"
+	SentinelWorkerStatusStuck   = "stuck"
+)
+
+// SentinelWorker is what a sentinel worker of this replica is doing, Job is nil when the worker is idle
+type SentinelWorker struct {
+	ID        int
+	Job       *SyncJob
+	StartedAt *time.Time
+	Deadline  *time.Time
+	// Stuck is set when the worker is still busy with a job past its deadline, the job has gone back to the queue
+	Stuck bool
+}
+
+type SentinelWorkers struct {
+	Workers    []*SentinelWorker
+	QueueDepth int64
+}
+
+func (w *SentinelWorker) Status() string {
+	if w.Job == nil {
+		return SentinelWorkerStatusIdle
+	}
+	if w.Stuck {
+		return SentinelWorkerStatusStuck
+	}
+	return SentinelWorkerStatusRunning
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	SyncJobStatusQueued    = "queued"
+	SyncJobStatusRunning   = "running"
+	SyncJobStatusSucceeded = SyncStatusSucceeded
+	SyncJobStatusFailed    = SyncStatusFailed
+)
+
+type SyncJob struct {
+	ID          uint
+	Application *Application
+	RunID       *uint
+	Trigger     string
+	Status      string
+	Attempts    int
+	AvailableAt time.Time
+	ClaimedBy   string
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	Error       string
+}
@@ -0,0 +1,35 @@
+package model
+
+// SyncPreview is what a sync of an application would change, computed without persisting anything
+type SyncPreview struct {
+	Application  *Application
+	CommitSha    string
+	Dependencies *DependenciesSyncPreview
+	OpenAPI      *OpenAPISyncPreview
+}
+
+type DependenciesSyncPreview struct {
+	Sha                  string
+	DependenciesToUpsert []*ApplicationDependency
+	PendingDependencies  []*PendingApplicationDependency
+	DependenciesToDelete []*ApplicationDependency
+	Err                  error
+}
+
+type OpenAPISyncPreview struct {
+	Sha string
+	// HasStoredSpecification is false when the application has no OpenAPI specification stored yet, there is nothing to compare against
+	HasStoredSpecification bool
+	Changes                []*OpenAPIChange
+	Err                    error
+}
+
+type OpenAPIChange struct {
+	ID        string
+	Level     string
+	Operation string
+	Path      string
+	Message   string
+	// AffectedConsumers are the applications declaring the changed operation in their openclient.json, sync previews leave it empty
+	AffectedConsumers []string
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	SyncErrorCategoryGitFetch   = "git_fetch"
+	SyncErrorCategoryParse      = "parse"
+	SyncErrorCategoryValidation = "validation"
+	SyncErrorCategoryStorage    = "storage"
+	SyncErrorCategoryRateLimit  = "rate_limit"
+)
+
+type SyncStatus struct {
+	LastAttemptAt                *time.Time
+	LastSuccessAt                *time.Time
+	LastError                    string
+	LastErrorCategory            string
+	ConsecutiveFailures          int
+	ConsecutivePermanentFailures int
+	QuarantinedAt                *time.Time
+}
+
+type SyncError struct {
+	Category string
+	Err      error
+}
+
+func NewSyncError(category string, err error) *SyncError {
+	return &SyncError{
+		Category: category,
+		Err:      err,
+	}
+}
+
+func (e *SyncError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SyncError) Unwrap() error {
+	return e.Err
+}
+
+func SyncErrorCategory(err error) string {
+	var syncErr *SyncError
+	if errors.As(err, &syncErr) {
+		return syncErr.Category
+	}
+	return ""
+}
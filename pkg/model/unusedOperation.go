@@ -0,0 +1,19 @@
+package model
+
+// UnusedOperationsReport lists the operations of the stored specification of an application no registered consumer
+// declares, the deprecated ones being kept apart as they are expected to be unused
+type UnusedOperationsReport struct {
+	Application *Application
+	// HasStoredSpecification is false when the application has no OpenAPI specification stored, nothing can be reported
+	HasStoredSpecification     bool
+	UnusedOperations           []*UnusedOperation
+	DeprecatedUnusedOperations []*UnusedOperation
+}
+
+type UnusedOperation struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Deprecated  bool
+}
@@ -180,14 +180,12 @@ func (handler *handler) handleUpdateApplication(e *gin.Context) {
 			RepositoryName:   updateRequest.GitInformation.RepositoryName,
 			RepositoryBranch: updateRequest.GitInformation.RepositoryBranch,
 		}
-		if updateRequest.MonitoringInformation != nil {
-			updateData.MonitoringInformation = &model.MonitoringInformation{
-				HasOpenApi:     updateRequest.MonitoringInformation.HasOpenAPI,
-				HasOpenClient:  updateRequest.MonitoringInformation.HasOpenClient,
-				OpenApiPath:    updateRequest.MonitoringInformation.OpenAPIPath,
-				OpenClientPath: updateRequest.MonitoringInformation.OpenClientPath,
-			}
-		}
+	}
+
+	// The schedule of the application can be updated without its git information
+	if updateRequest.MonitoringInformation != nil {
+		updateData.MonitoringInformation = handler.translator.ToMonitoringInformationModel(updateRequest.MonitoringInformation)
+		updateData.SyncEnabled = updateRequest.MonitoringInformation.SyncEnabled
 	}
 
 	updatedApp, err := handler.applicationService.UpdateApplication(e, applicationName, updateData)
@@ -26,6 +26,7 @@ func TestHandleCreateApplication(t *testing.T) {
 func TestHandleCreateApplicationWithGitInformation(t *testing.T) {
 	t.Run("success - create application with git information", handleCreateApplicationWithGitInformationSuccess)
 	t.Run("failure - git information provider required", handleCreateApplicationGitInformationProviderRequired)
+	t.Run("failure - git information provider unknown", handleCreateApplicationGitInformationProviderUnknown)
 	t.Run("failure - git information repository owner required", handleCreateApplicationGitInformationRepositoryOwnerRequired)
 	t.Run("failure - git information repository name required", handleCreateApplicationGitInformationRepositoryNameRequired)
 	t.Run("failure - git information repository branch required", handleCreateApplicationGitInformationRepositoryBranchRequired)
@@ -59,6 +60,7 @@ func TestHandleDeleteApplication(t *testing.T) {
 func TestHandleUpdateApplication(t *testing.T) {
 	t.Run("success - update application", handleUpdateApplicationSuccess)
 	t.Run("success - update application with git information", handleUpdateApplicationWithGitInformationSuccess)
+	t.Run("success - update application schedule without git information", handleUpdateApplicationScheduleSuccess)
 	t.Run("failure - update application with partial git information", handleUpdateApplicationWithPartialGitInformationError)
 	t.Run("failure - update application error", handleUpdateApplicationError)
 }
@@ -858,6 +860,43 @@ func handleCreateApplicationGitInformationProviderRequired(t *testing.T) {
 	require.Contains(t, actualResponse.Error, "provider")
 }
 
+func handleCreateApplicationGitInformationProviderUnknown(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mockedCreateApplicationRequest := &api.CreateApplicationRequest{
+		Name:        "test-app",
+		Description: "Test application description",
+		Team:        "test-team",
+		GitInformation: &api.GitInformation{
+			Provider:         "subversion",
+			RepositoryOwner:  "test-owner",
+			RepositoryName:   "test-repo",
+			RepositoryBranch: "main",
+		},
+	}
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	url := "/applications"
+
+	request, recorder, err := test.NewHTTPRequest("POST", url, mockedCreateApplicationRequest)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.ErrorResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code, "Expected status code 400")
+	require.Contains(t, actualResponse.Error, "provider must be one of")
+}
+
 func handleCreateApplicationGitInformationRepositoryOwnerRequired(t *testing.T) {
 	router, mocks := setUp(t)
 
@@ -1134,6 +1173,49 @@ func handleUpdateApplicationWithGitInformationSuccess(t *testing.T) {
 	require.Equal(t, expectedResponse, &actualResponse, "Response body mismatch")
 }
 
+func handleUpdateApplicationScheduleSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mockedName := "test-app"
+	syncInterval := 600
+
+	mockedUpdateRequest := &api.UpdateApplicationRequest{
+		MonitoringInformation: &api.MonitoringInformation{
+			HasOpenAPI:   true,
+			OpenAPIPath:  "docs/openapi.json",
+			SyncInterval: &syncInterval,
+		},
+	}
+
+	// syncEnabled is omitted, the application keeps its current value
+	expectedUpdateData := &model.ApplicationUpdate{
+		MonitoringInformation: &model.MonitoringInformation{
+			HasOpenApi:   true,
+			OpenApiPath:  "docs/openapi.json",
+			SyncInterval: &syncInterval,
+			SyncEnabled:  true,
+		},
+	}
+
+	mocks.applicationServiceMock.EXPECT().
+		UpdateApplication(gomock.Any(), mockedName, expectedUpdateData).
+		Return(&model.Application{Name: mockedName}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	url := "/applications/" + mockedName
+
+	request, recorder, err := test.NewHTTPRequest("PUT", url, mockedUpdateRequest)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code, "Expected status code 200")
+}
+
 func handleUpdateApplicationWithPartialGitInformationError(t *testing.T) {
 	router, mocks := setUp(t)
 
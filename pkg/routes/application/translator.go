@@ -93,6 +93,24 @@ func (t *translator) ToApplicationApi(applicationModel *model.Application) *api.
 		GitInformation:        t.ToApiGitInformation(applicationModel.GitInformation),
 		MonitoringInformation: t.ToMonitoringInformationApi(applicationModel.MonitoringInformation),
 		Token:                 t.ToTokenApi(applicationModel.Token),
+		SyncStatus:            t.ToSyncStatusApi(applicationModel.SyncStatus),
+	}
+}
+
+func (t *translator) ToSyncStatusApi(syncStatus *model.SyncStatus) *api.SyncStatus {
+	if syncStatus == nil {
+		return nil
+	}
+
+	return &api.SyncStatus{
+		LastAttemptAt:                syncStatus.LastAttemptAt,
+		LastSuccessAt:                syncStatus.LastSuccessAt,
+		LastError:                    syncStatus.LastError,
+		LastErrorCategory:            syncStatus.LastErrorCategory,
+		ConsecutiveFailures:          syncStatus.ConsecutiveFailures,
+		ConsecutivePermanentFailures: syncStatus.ConsecutivePermanentFailures,
+		Quarantined:                  syncStatus.QuarantinedAt != nil,
+		QuarantinedAt:                syncStatus.QuarantinedAt,
 	}
 }
 
@@ -130,6 +148,7 @@ func (t *translator) ToGitInformationModel(gitInfo *api.GitInformation) *model.G
 	}
 }
 
+// ToMonitoringInformationModel enables the sync when syncEnabled is omitted, updates read it from the request instead
 func (t *translator) ToMonitoringInformationModel(monitoringInfo *api.MonitoringInformation) *model.MonitoringInformation {
 	if monitoringInfo == nil {
 		return nil
@@ -140,6 +159,8 @@ func (t *translator) ToMonitoringInformationModel(monitoringInfo *api.Monitoring
 		OpenApiPath:    monitoringInfo.OpenAPIPath,
 		HasOpenClient:  monitoringInfo.HasOpenClient,
 		OpenClientPath: monitoringInfo.OpenClientPath,
+		SyncInterval:   monitoringInfo.SyncInterval,
+		SyncEnabled:    monitoringInfo.SyncEnabled == nil || *monitoringInfo.SyncEnabled,
 	}
 }
 
@@ -148,10 +169,14 @@ func (t *translator) ToMonitoringInformationApi(monitoringInfo *model.Monitoring
 		return nil
 	}
 
+	syncEnabled := monitoringInfo.SyncEnabled
+
 	return &api.MonitoringInformation{
 		HasOpenAPI:     monitoringInfo.HasOpenApi,
 		OpenAPIPath:    monitoringInfo.OpenApiPath,
 		HasOpenClient:  monitoringInfo.HasOpenClient,
 		OpenClientPath: monitoringInfo.OpenClientPath,
+		SyncInterval:   monitoringInfo.SyncInterval,
+		SyncEnabled:    &syncEnabled,
 	}
 }
@@ -73,11 +73,15 @@ func (t *translator) ToMonitoringInformationApi(monitoringInfo *model.Monitoring
 		return nil
 	}
 
+	syncEnabled := monitoringInfo.SyncEnabled
+
 	return &api.MonitoringInformation{
 		HasOpenAPI:     monitoringInfo.HasOpenApi,
 		OpenAPIPath:    monitoringInfo.OpenApiPath,
 		HasOpenClient:  monitoringInfo.HasOpenClient,
 		OpenClientPath: monitoringInfo.OpenClientPath,
+		SyncInterval:   monitoringInfo.SyncInterval,
+		SyncEnabled:    &syncEnabled,
 	}
 }
 
@@ -9,11 +9,21 @@ import (
 	"cosmos-server/pkg/services/monitoring"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultSentinelRunsPageSize    = 20
+	defaultOpenAPIVersionsPageSize = 20
+
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+)
+
 type handler struct {
 	monitoringService  monitoring.Service
 	applicationService application.Service
@@ -32,10 +42,24 @@ func AddAuthenticatedMonitoringHandler(e *gin.RouterGroup, monitoringService mon
 	monitoringGroup := e.Group("/monitoring")
 
 	monitoringGroup.POST("/update/:application", handler.handleUpdateApplicationMonitoring)
+	monitoringGroup.POST("/preview/:application", handler.handlePreviewApplicationSync)
+	monitoringGroup.GET("/jobs/:job", handler.handleGetSyncJob)
 	monitoringGroup.GET("/interactions/:application", handler.handleGetApplicationInteractions)
 	monitoringGroup.GET("/interactions", handler.handleGetApplicationsInteractions)
 	monitoringGroup.GET("/interactions/group/:group", handler.handleGetGroupApplicationsInteractions)
 	monitoringGroup.GET("/openapi/:application", handler.handleGetApplicationOpenAPISpecification)
+	monitoringGroup.GET("/openapi/:application/versions", handler.handleGetApplicationOpenAPISpecificationVersions)
+	monitoringGroup.GET("/openapi/:application/versions/:version", handler.handleGetApplicationOpenAPISpecificationVersion)
+	monitoringGroup.GET("/openapi/:application/diff", handler.handleGetApplicationOpenAPISpecificationDiff)
+	monitoringGroup.POST("/openapi/:application/check", handler.handleCheckApplicationOpenAPISpecification)
+	monitoringGroup.GET("/openapi/:application/retention", handler.handleGetApplicationOpenAPIRetention)
+	monitoringGroup.PUT("/openapi/:application/retention", handler.handleUpdateApplicationOpenAPIRetention)
+	monitoringGroup.POST("/openclient/:application/validate", handler.handleValidateApplicationOpenClientSpecification)
+	monitoringGroup.GET("/consumers/:application", handler.handleGetProviderOperations)
+	monitoringGroup.GET("/unused/:application", handler.handleGetApplicationUnusedOperations)
+	monitoringGroup.GET("/unused/team/:team", handler.handleGetTeamUnusedOperations)
+	monitoringGroup.GET("/drift", handler.handleGetContractDrifts)
+	monitoringGroup.GET("/drift/:application", handler.handleGetApplicationContractDrifts)
 	monitoringGroup.GET("/complete/:application", handler.handleGetCompleteApplicationMonitoring)
 }
 
@@ -51,6 +75,12 @@ func AddAdminMonitoringHandler(e *gin.RouterGroup, monitoringService monitoring.
 
 	monitoringGroup.PUT("/sentinel/settings", handler.handleUpdateSentinelConfiguration)
 	monitoringGroup.GET("/sentinel/settings", handler.handleGetSentinelConfiguration)
+	monitoringGroup.GET("/sentinel/runs", handler.handleGetSentinelRuns)
+	monitoringGroup.GET("/sentinel/runs/:run", handler.handleGetSentinelRun)
+	monitoringGroup.POST("/sentinel/sync", handler.handleSyncAllApplications)
+	monitoringGroup.GET("/sentinel/workers", handler.handleGetSentinelWorkers)
+	monitoringGroup.GET("/git/rate-limits", handler.handleGetGitRateLimits)
+	monitoringGroup.DELETE("/quarantine/:application", handler.handleReleaseApplicationQuarantine)
 }
 
 func (handler *handler) handleUpdateApplicationMonitoring(e *gin.Context) {
@@ -63,19 +93,70 @@ func (handler *handler) handleUpdateApplicationMonitoring(e *gin.Context) {
 		return
 	}
 
-	err = handler.monitoringService.UpdateApplicationDependencies(e, applicationToUpdate)
+	jobs, err := handler.monitoringService.EnqueueApplicationsSync(e, model.SentinelRunTriggerManual, []*model.Application{applicationToUpdate})
 	if err != nil {
+		handler.logger.Errorf("Failed to enqueue sync of application %s: %v", applicationName, err)
 		_ = e.Error(err)
 		return
 	}
 
-	err = handler.monitoringService.UpdateApplicationOpenAPISpecification(e, applicationToUpdate)
+	if len(jobs) == 0 {
+		_ = e.Error(errors.NewInternalServerError(fmt.Sprintf("no sync job was queued for application %s", applicationName)))
+		return
+	}
+
+	e.JSON(http.StatusAccepted, handler.translator.ToSyncApplicationResponse(jobs[0]))
+}
+
+func (handler *handler) handlePreviewApplicationSync(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	// The body is optional, an empty one previews a sync of the application as it is stored
+	var previewRequest api.PreviewApplicationSyncRequest
+	if e.Request.ContentLength != 0 {
+		if err := e.ShouldBindJSON(&previewRequest); err != nil {
+			_ = e.Error(errors.NewBadRequestError(fmt.Sprintf("Invalid request format: %v", err)))
+			return
+		}
+	}
+
+	if err := previewRequest.Validate(); err != nil {
+		_ = e.Error(errors.NewBadRequestError(err.Error()))
+		return
+	}
+
+	previewedApplication, err := handler.applicationService.GetApplication(e, applicationName)
 	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
 		_ = e.Error(err)
 		return
 	}
 
-	e.JSON(http.StatusNoContent, nil)
+	preview, err := handler.monitoringService.PreviewApplicationSync(e, previewedApplication, handler.translator.ToSyncPreviewChangesModel(&previewRequest))
+	if err != nil {
+		handler.logger.Errorf("Failed to preview sync of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToPreviewApplicationSyncResponse(preview))
+}
+
+func (handler *handler) handleGetSyncJob(e *gin.Context) {
+	jobID, err := strconv.ParseUint(e.Param("job"), 10, 0)
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("job id must be a positive number"))
+		return
+	}
+
+	job, err := handler.monitoringService.GetSyncJob(e, uint(jobID))
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve sync job %d: %v", jobID, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetSyncJobResponse(job))
 }
 
 func (handler *handler) handleGetApplicationInteractions(e *gin.Context) {
@@ -98,6 +179,148 @@ func (handler *handler) handleGetApplicationInteractions(e *gin.Context) {
 	e.JSON(200, handler.translator.ToGetApplicationsInteractionsResponse(interactions))
 }
 
+// handleGetProviderOperations lists who calls the operations of the application, the optional method and path query
+// parameters narrow it to one operation
+func (handler *handler) handleGetProviderOperations(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	provider, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	filter := model.EndpointConsumerFilter{
+		Method: e.Query("method"),
+		Path:   e.Query("path"),
+	}
+
+	operations, err := handler.monitoringService.GetProviderOperations(e, provider, filter)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve the consumers of the operations of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetProviderOperationsResponse(provider, operations))
+}
+
+func (handler *handler) handleGetApplicationUnusedOperations(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	format, err := getReportFormat(e)
+	if err != nil {
+		_ = e.Error(err)
+		return
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	report, err := handler.monitoringService.GetApplicationUnusedOperations(e, evaluatedApplication)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve unused operations of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	if format == reportFormatCSV {
+		handler.writeUnusedOperationsCSV(e, applicationName, []*model.UnusedOperationsReport{report})
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetUnusedOperationsResponse(report))
+}
+
+func (handler *handler) handleGetTeamUnusedOperations(e *gin.Context) {
+	teamName := e.Param("team")
+
+	format, err := getReportFormat(e)
+	if err != nil {
+		_ = e.Error(err)
+		return
+	}
+
+	applications, err := handler.applicationService.GetApplicationsByTeam(e, teamName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve applications of team %s: %v", teamName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	reports, err := handler.monitoringService.GetApplicationsUnusedOperations(e, applications)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve unused operations of team %s: %v", teamName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	if format == reportFormatCSV {
+		handler.writeUnusedOperationsCSV(e, teamName, reports)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetTeamUnusedOperationsResponse(teamName, reports))
+}
+
+func (handler *handler) writeUnusedOperationsCSV(e *gin.Context, name string, reports []*model.UnusedOperationsReport) {
+	content, err := handler.translator.ToUnusedOperationsCSV(reports)
+	if err != nil {
+		handler.logger.Errorf("Failed to write unused operations of %s as CSV: %v", name, err)
+		_ = e.Error(errors.NewInternalServerError(fmt.Sprintf("failed to write unused operations as CSV: %v", err)))
+		return
+	}
+
+	e.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"-unused-operations.csv"))
+	e.Data(http.StatusOK, "text/csv", content)
+}
+
+// getReportFormat reads the optional format query parameter of the reports, json by default
+func getReportFormat(e *gin.Context) (string, error) {
+	format := e.DefaultQuery("format", reportFormatJSON)
+	if format != reportFormatJSON && format != reportFormatCSV {
+		return "", errors.NewBadRequestError(fmt.Sprintf("format must be %s or %s", reportFormatJSON, reportFormatCSV))
+	}
+
+	return format, nil
+}
+
+func (handler *handler) handleGetApplicationContractDrifts(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	drifts, err := handler.monitoringService.GetApplicationContractDrifts(e, evaluatedApplication)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve contract drifts of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetContractDriftsResponse(drifts))
+}
+
+func (handler *handler) handleGetContractDrifts(e *gin.Context) {
+	drifts, err := handler.monitoringService.GetContractDrifts(e)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve contract drifts: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetContractDriftsResponse(drifts))
+}
+
 func (handler *handler) handleGetApplicationsInteractions(e *gin.Context) {
 	teamsParam := e.Query("teams")
 	includeNeighbors := e.Query("includeNeighbors") == "true"
@@ -149,6 +372,254 @@ func (handler *handler) handleGetApplicationOpenAPISpecification(e *gin.Context)
 	e.JSON(200, getOpenApiSpecificationResponse)
 }
 
+// handleGetApplicationOpenAPISpecificationVersions lists the versions newest first. With the "at" query parameter only
+// the versions ingested up to that time are listed, so the first one is the specification the application had then.
+func (handler *handler) handleGetApplicationOpenAPISpecificationVersions(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	page, err := strconv.Atoi(e.DefaultQuery("page", "1"))
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("page must be a number"))
+		return
+	}
+
+	pageSize, err := strconv.Atoi(e.DefaultQuery("pageSize", strconv.Itoa(defaultOpenAPIVersionsPageSize)))
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("pageSize must be a number"))
+		return
+	}
+
+	var at *time.Time
+	if atParam := e.Query("at"); atParam != "" {
+		parsedAt, err := time.Parse(time.RFC3339, atParam)
+		if err != nil {
+			_ = e.Error(errors.NewBadRequestError("at must be an RFC 3339 timestamp"))
+			return
+		}
+		at = &parsedAt
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	versionsPage, err := handler.monitoringService.GetApplicationOpenAPISpecificationVersions(e, evaluatedApplication, at, page, pageSize)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve OpenAPI specification versions of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetOpenAPISpecificationVersionsResponse(evaluatedApplication, versionsPage))
+}
+
+func (handler *handler) handleGetApplicationOpenAPISpecificationVersion(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	versionID, err := strconv.ParseUint(e.Param("version"), 10, 0)
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("version id must be a positive number"))
+		return
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	version, err := handler.monitoringService.GetApplicationOpenAPISpecificationVersion(e, evaluatedApplication, uint(versionID))
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve OpenAPI specification version %d of application %s: %v", versionID, applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	getOpenAPISpecificationVersionResponse, err := handler.translator.ToGetOpenAPISpecificationVersionResponse(evaluatedApplication, version)
+	if err != nil {
+		handler.logger.Errorf("Failed to translate OpenAPI specification version: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, getOpenAPISpecificationVersionResponse)
+}
+
+// handleGetApplicationOpenAPISpecificationDiff compares a base and a revision, each given either as a stored version id
+// (baseVersion, revisionVersion) or as a git ref of the repository of the application (baseRef, revisionRef)
+func (handler *handler) handleGetApplicationOpenAPISpecificationDiff(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	base, err := getOpenAPISpecificationReference(e, "base")
+	if err != nil {
+		_ = e.Error(err)
+		return
+	}
+
+	revision, err := getOpenAPISpecificationReference(e, "revision")
+	if err != nil {
+		_ = e.Error(err)
+		return
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	diff, err := handler.monitoringService.CompareApplicationOpenAPISpecifications(e, evaluatedApplication, base, revision, e.Query("format"))
+	if err != nil {
+		handler.logger.Errorf("Failed to compare OpenAPI specifications of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetOpenAPISpecificationDiffResponse(diff))
+}
+
+// handleCheckApplicationOpenAPISpecification answers with 200 whatever the verdict, CI pipelines read it from "passed"
+func (handler *handler) handleCheckApplicationOpenAPISpecification(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	var checkRequest api.CheckOpenAPISpecificationRequest
+	if err := e.ShouldBindJSON(&checkRequest); err != nil {
+		_ = e.Error(errors.NewBadRequestError(fmt.Sprintf("Invalid request format: %v", err)))
+		return
+	}
+
+	if err := checkRequest.Validate(); err != nil {
+		_ = e.Error(errors.NewBadRequestError(err.Error()))
+		return
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	check, err := handler.monitoringService.CheckApplicationOpenAPISpecification(e, evaluatedApplication, checkRequest.OpenAPISpec, checkRequest.FailOn)
+	if err != nil {
+		handler.logger.Errorf("Failed to check candidate OpenAPI specification of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToCheckOpenAPISpecificationResponse(check))
+}
+
+// handleValidateApplicationOpenClientSpecification answers with 200 whatever the outcome, callers read it from "valid"
+func (handler *handler) handleValidateApplicationOpenClientSpecification(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	var validateRequest api.ValidateOpenClientSpecificationRequest
+	if err := e.ShouldBindJSON(&validateRequest); err != nil {
+		_ = e.Error(errors.NewBadRequestError(fmt.Sprintf("Invalid request format: %v", err)))
+		return
+	}
+
+	if err := validateRequest.Validate(); err != nil {
+		_ = e.Error(errors.NewBadRequestError(err.Error()))
+		return
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	openClientValidation, err := handler.monitoringService.ValidateApplicationOpenClientSpecification(e, evaluatedApplication, validateRequest.OpenClientSpec)
+	if err != nil {
+		handler.logger.Errorf("Failed to validate candidate openclient.json of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToValidateOpenClientSpecificationResponse(openClientValidation))
+}
+
+// getOpenAPISpecificationReference expects exactly one of the <side>Version and <side>Ref query parameters
+func getOpenAPISpecificationReference(e *gin.Context, side string) (*model.OpenAPISpecificationReference, error) {
+	versionParam := e.Query(side + "Version")
+	refParam := e.Query(side + "Ref")
+
+	if (versionParam == "") == (refParam == "") {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("either %sVersion or %sRef must be set", side, side))
+	}
+
+	if refParam != "" {
+		return &model.OpenAPISpecificationReference{GitRef: refParam}, nil
+	}
+
+	versionID, err := strconv.ParseUint(versionParam, 10, 0)
+	if err != nil {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("%sVersion must be a positive number", side))
+	}
+
+	id := uint(versionID)
+	return &model.OpenAPISpecificationReference{VersionID: &id}, nil
+}
+
+func (handler *handler) handleGetApplicationOpenAPIRetention(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	retention, err := handler.monitoringService.GetApplicationOpenAPIRetention(e, evaluatedApplication)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve OpenAPI specification retention of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetOpenAPIRetentionResponse(retention))
+}
+
+func (handler *handler) handleUpdateApplicationOpenAPIRetention(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	var updateRetentionRequest api.UpdateOpenAPIRetentionRequest
+	if err := e.ShouldBindJSON(&updateRetentionRequest); err != nil {
+		_ = e.Error(errors.NewBadRequestError(fmt.Sprintf("Invalid request format: %v", err)))
+		return
+	}
+
+	if err := updateRetentionRequest.Validate(); err != nil {
+		_ = e.Error(errors.NewBadRequestError(err.Error()))
+		return
+	}
+
+	evaluatedApplication, err := handler.applicationService.GetApplication(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve application: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	err = handler.monitoringService.UpdateApplicationOpenAPIRetention(e, evaluatedApplication, handler.translator.ToOpenAPIRetentionModel(&updateRetentionRequest))
+	if err != nil {
+		handler.logger.Errorf("Failed to update OpenAPI specification retention of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusNoContent, nil)
+}
+
 func (handler *handler) handleGetCompleteApplicationMonitoring(e *gin.Context) {
 	applicationName := e.Param("application")
 
@@ -226,3 +697,91 @@ func (handler *handler) handleGetGroupApplicationsInteractions(e *gin.Context) {
 
 	e.JSON(200, handler.translator.ToGetApplicationsInteractionsResponse(interactions))
 }
+
+func (handler *handler) handleGetSentinelRuns(e *gin.Context) {
+	page, err := strconv.Atoi(e.DefaultQuery("page", "1"))
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("page must be a number"))
+		return
+	}
+
+	pageSize, err := strconv.Atoi(e.DefaultQuery("pageSize", strconv.Itoa(defaultSentinelRunsPageSize)))
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("pageSize must be a number"))
+		return
+	}
+
+	runsPage, err := handler.monitoringService.GetSentinelRuns(e, page, pageSize)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve sentinel runs: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetSentinelRunsResponse(runsPage))
+}
+
+func (handler *handler) handleGetSentinelRun(e *gin.Context) {
+	runID, err := strconv.ParseUint(e.Param("run"), 10, 0)
+	if err != nil {
+		_ = e.Error(errors.NewBadRequestError("run id must be a positive number"))
+		return
+	}
+
+	run, err := handler.monitoringService.GetSentinelRun(e, uint(runID))
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve sentinel run %d: %v", runID, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetSentinelRunResponse(run))
+}
+
+func (handler *handler) handleSyncAllApplications(e *gin.Context) {
+	applications, err := handler.applicationService.GetApplicationsToMonitor(e)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve applications to monitor: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	jobs, err := handler.monitoringService.EnqueueApplicationsSync(e, model.SentinelRunTriggerManual, applications)
+	if err != nil {
+		handler.logger.Errorf("Failed to enqueue sync of all applications: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusAccepted, handler.translator.ToSyncAllApplicationsResponse(jobs))
+}
+
+func (handler *handler) handleGetSentinelWorkers(e *gin.Context) {
+	workers, err := handler.monitoringService.GetSentinelWorkers(e)
+	if err != nil {
+		handler.logger.Errorf("Failed to retrieve sentinel workers: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusOK, handler.translator.ToGetSentinelWorkersResponse(workers))
+}
+
+func (handler *handler) handleGetGitRateLimits(e *gin.Context) {
+	rateLimits := handler.monitoringService.GetGitRateLimits(e)
+
+	e.JSON(http.StatusOK, handler.translator.ToGetGitRateLimitsResponse(rateLimits))
+}
+
+func (handler *handler) handleReleaseApplicationQuarantine(e *gin.Context) {
+	applicationName := e.Param("application")
+
+	err := handler.monitoringService.ReleaseApplicationQuarantine(e, applicationName)
+	if err != nil {
+		handler.logger.Errorf("Failed to release quarantine of application %s: %v", applicationName, err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusNoContent, nil)
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,21 @@ func TestHandleUpdateApplicationMonitoring(t *testing.T) {
 	t.Run("failure - internal server error", handleUpdateApplicationMonitoringInternalServerError)
 }
 
+func TestHandlePreviewApplicationSync(t *testing.T) {
+	t.Run("success - preview application sync", handlePreviewApplicationSyncSuccess)
+	t.Run("failure - invalid git information", handlePreviewApplicationSyncInvalidGitInformation)
+}
+
+func TestHandleGetSyncJob(t *testing.T) {
+	t.Run("success - get sync job", handleGetSyncJobSuccess)
+	t.Run("failure - invalid job id", handleGetSyncJobInvalidID)
+	t.Run("failure - job not found", handleGetSyncJobNotFound)
+}
+
+func TestHandleSyncAllApplications(t *testing.T) {
+	t.Run("success - sync all applications", handleSyncAllApplicationsSuccess)
+}
+
 func TestHandleGetApplicationInteractions(t *testing.T) {
 	t.Run("success - get application interactions", handleGetApplicationInteractionsSuccess)
 	t.Run("failure - application not found", handleGetApplicationInteractionsApplicationNotFound)
@@ -36,6 +52,53 @@ func TestHandleGetApplicationsInteractions(t *testing.T) {
 	t.Run("failure - monitoring service internal error", handleGetApplicationsInteractionsInternalServerError)
 }
 
+func TestHandleGetSentinelRuns(t *testing.T) {
+	t.Run("success - get sentinel runs", handleGetSentinelRunsSuccess)
+	t.Run("failure - invalid page", handleGetSentinelRunsInvalidPage)
+	t.Run("failure - run not found", handleGetSentinelRunNotFound)
+}
+
+func TestHandleReleaseApplicationQuarantine(t *testing.T) {
+	t.Run("success - release application quarantine", handleReleaseApplicationQuarantineSuccess)
+	t.Run("failure - application not found", handleReleaseApplicationQuarantineNotFound)
+}
+
+func TestHandleGetSentinelWorkers(t *testing.T) {
+	t.Run("success - get sentinel workers", handleGetSentinelWorkersSuccess)
+}
+
+func TestHandleGetGitRateLimits(t *testing.T) {
+	t.Run("success - get git rate limits", handleGetGitRateLimitsSuccess)
+}
+
+func TestHandleApplicationOpenAPISpecificationVersions(t *testing.T) {
+	t.Run("success - list versions at a time", handleGetApplicationOpenAPISpecificationVersionsAtTime)
+	t.Run("failure - invalid at timestamp", handleGetApplicationOpenAPISpecificationVersionsInvalidAt)
+	t.Run("success - diff between a version and a git ref", handleGetApplicationOpenAPISpecificationDiffSuccess)
+	t.Run("failure - diff with both a version and a git ref", handleGetApplicationOpenAPISpecificationDiffAmbiguousReference)
+	t.Run("success - check a candidate specification", handleCheckApplicationOpenAPISpecificationSuccess)
+	t.Run("failure - check without a candidate specification", handleCheckApplicationOpenAPISpecificationMissingSpecification)
+	t.Run("success - update retention", handleUpdateApplicationOpenAPIRetentionSuccess)
+	t.Run("failure - invalid retention", handleUpdateApplicationOpenAPIRetentionInvalid)
+	t.Run("success - validate a candidate openclient.json", handleValidateApplicationOpenClientSpecificationSuccess)
+}
+
+func TestHandleGetProviderOperations(t *testing.T) {
+	t.Run("success - consumers of an operation", handleGetProviderOperationsSuccess)
+	t.Run("failure - invalid method", handleGetProviderOperationsInvalidMethod)
+}
+
+func TestHandleGetUnusedOperations(t *testing.T) {
+	t.Run("success - application unused operations as JSON", handleGetApplicationUnusedOperationsSuccess)
+	t.Run("success - team unused operations as CSV", handleGetTeamUnusedOperationsCSV)
+	t.Run("failure - unknown format", handleGetApplicationUnusedOperationsInvalidFormat)
+}
+
+func TestHandleGetContractDrifts(t *testing.T) {
+	t.Run("success - application contract drifts", handleGetApplicationContractDriftsSuccess)
+	t.Run("success - organisation contract drifts", handleGetContractDriftsSuccess)
+}
+
 type mocks struct {
 	controller             *gomock.Controller
 	monitoringServiceMock  *monitoringMock.MockService
@@ -60,6 +123,7 @@ func setUp(t *testing.T) (*gin.Engine, *mocks) {
 	router := test.NewRouter(loggerMock)
 
 	AddAuthenticatedMonitoringHandler(router.Group("/"), monitoringServiceMock, applicationServiceMock, NewTranslator(), loggerMock)
+	AddAdminMonitoringHandler(router.Group("/admin"), monitoringServiceMock, applicationServiceMock, NewTranslator(), loggerMock)
 
 	return router, mocks
 }
@@ -90,13 +154,10 @@ func handleUpdateApplicationMonitoringSuccess(t *testing.T) {
 		GetApplication(gomock.Any(), mockedApplicationName).
 		Return(modelApplication, nil)
 
+	runID := uint(4)
 	mocks.monitoringServiceMock.EXPECT().
-		UpdateApplicationDependencies(gomock.Any(), modelApplication).
-		Return(nil)
-
-	mocks.monitoringServiceMock.EXPECT().
-		UpdateApplicationOpenAPISpecification(gomock.Any(), modelApplication).
-		Return(nil)
+		EnqueueApplicationsSync(gomock.Any(), model.SentinelRunTriggerManual, []*model.Application{modelApplication}).
+		Return([]*model.SyncJob{{ID: 12, Application: modelApplication, RunID: &runID, Trigger: model.SentinelRunTriggerManual, Status: model.SyncJobStatusQueued}}, nil)
 
 	mocks.loggerMock.EXPECT().
 		Infow(gomock.Any(), gomock.Any())
@@ -110,7 +171,14 @@ func handleUpdateApplicationMonitoringSuccess(t *testing.T) {
 
 	router.ServeHTTP(recorder, request)
 
-	require.Equal(t, http.StatusNoContent, recorder.Code, "Expected status code 204 No Content")
+	actualResponse := api.SyncApplicationResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusAccepted, recorder.Code, "Expected status code 202 Accepted")
+	require.Equal(t, uint(12), actualResponse.Job.ID)
+	require.Equal(t, mockedApplicationName, actualResponse.Job.ApplicationName)
+	require.Equal(t, model.SyncJobStatusQueued, actualResponse.Job.Status)
 }
 
 func handleUpdateApplicationMonitoringApplicationNotFound(t *testing.T) {
@@ -178,12 +246,15 @@ func handleUpdateApplicationMonitoringInternalServerError(t *testing.T) {
 		Return(modelApplication, nil)
 
 	mocks.monitoringServiceMock.EXPECT().
-		UpdateApplicationDependencies(gomock.Any(), modelApplication).
-		Return(mockedError)
+		EnqueueApplicationsSync(gomock.Any(), model.SentinelRunTriggerManual, []*model.Application{modelApplication}).
+		Return(nil, mockedError)
 
 	mocks.loggerMock.EXPECT().
 		Infow(gomock.Any(), gomock.Any())
 
+	mocks.loggerMock.EXPECT().
+		Errorf(gomock.Any(), gomock.Any())
+
 	url := fmt.Sprintf("/monitoring/update/%s", mockedApplicationName)
 
 	request, recorder, err := test.NewHTTPRequest("POST", url, nil)
@@ -467,3 +538,939 @@ func handleGetApplicationsInteractionsInternalServerError(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, recorder.Code)
 	require.Equal(t, "internal error", actualResponse.Error)
 }
+
+func handleGetSentinelRunsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(time.Minute)
+
+	runsPage := &model.SentinelRunsPage{
+		Runs: []*model.SentinelRun{
+			{
+				ID:         3,
+				Trigger:    model.SentinelRunTriggerScheduled,
+				StartedAt:  startedAt,
+				FinishedAt: &finishedAt,
+				Applications: []*model.SentinelRunApplication{
+					{ApplicationName: "service-a", Status: model.SyncStatusSucceeded},
+					{ApplicationName: "service-b", Status: model.SyncStatusFailed, Error: "boom"},
+				},
+			},
+		},
+		Page:     2,
+		PageSize: 5,
+		Total:    6,
+	}
+
+	expectedResponse := api.GetSentinelRunsResponse{
+		Runs: []*api.SentinelRunSummary{
+			{
+				ID:                      3,
+				Trigger:                 model.SentinelRunTriggerScheduled,
+				StartedAt:               startedAt,
+				FinishedAt:              &finishedAt,
+				ApplicationsCount:       2,
+				FailedApplicationsCount: 1,
+			},
+		},
+		Page:     2,
+		PageSize: 5,
+		Total:    6,
+	}
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetSentinelRuns(gomock.Any(), 2, 5).
+		Return(runsPage, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/admin/monitoring/sentinel/runs?page=2&pageSize=5", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetSentinelRunsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, expectedResponse, actualResponse)
+}
+
+func handleGetSentinelRunsInvalidPage(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/admin/monitoring/sentinel/runs?page=first", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func handleGetSentinelRunNotFound(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetSentinelRun(gomock.Any(), uint(9)).
+		Return(nil, errors.NewNotFoundError("sentinel run 9 not found"))
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	mocks.loggerMock.EXPECT().
+		Errorf(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/admin/monitoring/sentinel/runs/9", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.ErrorResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, "sentinel run 9 not found", actualResponse.Error)
+}
+
+func handleGetGitRateLimitsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	resetAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+
+	rateLimits := []*model.GitRateLimit{
+		{Provider: model.GitProviderGithub, Token: "default", Limit: 5000, Remaining: 0, Used: 5000, ResetAt: resetAt, UpdatedAt: updatedAt},
+		{Provider: model.GitProviderGithub, Token: "sha256:0123456789ab", Limit: 5000, Remaining: 4200, Used: 800, ResetAt: resetAt, UpdatedAt: updatedAt},
+	}
+
+	expectedResponse := api.GetGitRateLimitsResponse{
+		RateLimits: []*api.GitRateLimit{
+			{Provider: model.GitProviderGithub, Token: "default", Limit: 5000, Remaining: 0, Used: 5000, ResetAt: resetAt, Paused: true, UpdatedAt: updatedAt},
+			{Provider: model.GitProviderGithub, Token: "sha256:0123456789ab", Limit: 5000, Remaining: 4200, Used: 800, ResetAt: resetAt, Paused: false, UpdatedAt: updatedAt},
+		},
+	}
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetGitRateLimits(gomock.Any()).
+		Return(rateLimits)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/admin/monitoring/git/rate-limits", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetGitRateLimitsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, expectedResponse, actualResponse)
+}
+
+func handleReleaseApplicationQuarantineSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.monitoringServiceMock.EXPECT().
+		ReleaseApplicationQuarantine(gomock.Any(), "test-application").
+		Return(nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("DELETE", "/admin/monitoring/quarantine/test-application", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func handleReleaseApplicationQuarantineNotFound(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.monitoringServiceMock.EXPECT().
+		ReleaseApplicationQuarantine(gomock.Any(), "test-application").
+		Return(errors.NewNotFoundError("application test-application not found"))
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	mocks.loggerMock.EXPECT().
+		Errorf(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("DELETE", "/admin/monitoring/quarantine/test-application", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.ErrorResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, "application test-application not found", actualResponse.Error)
+}
+
+func handleGetSyncJobSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	startedAt := time.Now().UTC().Truncate(time.Second)
+	finishedAt := startedAt.Add(3 * time.Second)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetSyncJob(gomock.Any(), uint(12)).
+		Return(&model.SyncJob{
+			ID:          12,
+			Application: &model.Application{Name: "test-application"},
+			Trigger:     model.SentinelRunTriggerManual,
+			Status:      model.SyncJobStatusFailed,
+			Attempts:    1,
+			StartedAt:   &startedAt,
+			FinishedAt:  &finishedAt,
+			Error:       "failed to parse OpenAPI spec",
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/jobs/12", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetSyncJobResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "test-application", actualResponse.Job.ApplicationName)
+	require.Equal(t, model.SyncJobStatusFailed, actualResponse.Job.Status)
+	require.Equal(t, "failed to parse OpenAPI spec", actualResponse.Job.Error)
+	require.True(t, finishedAt.Equal(*actualResponse.Job.FinishedAt))
+}
+
+func handleGetSyncJobInvalidID(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/jobs/abc", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func handleGetSyncJobNotFound(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetSyncJob(gomock.Any(), uint(13)).
+		Return(nil, errors.NewNotFoundError("sync job 13 not found"))
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	mocks.loggerMock.EXPECT().
+		Errorf(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/jobs/13", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.ErrorResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, "sync job 13 not found", actualResponse.Error)
+}
+
+func handleSyncAllApplicationsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	applications := []*model.Application{{Name: "service-a"}, {Name: "service-b"}}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplicationsToMonitor(gomock.Any()).
+		Return(applications, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		EnqueueApplicationsSync(gomock.Any(), model.SentinelRunTriggerManual, applications).
+		Return([]*model.SyncJob{
+			{ID: 1, Application: applications[0], Status: model.SyncJobStatusQueued},
+			{ID: 2, Application: applications[1], Status: model.SyncJobStatusQueued},
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("POST", "/admin/monitoring/sentinel/sync", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.SyncAllApplicationsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+	require.Len(t, actualResponse.Jobs, 2)
+	require.Equal(t, "service-b", actualResponse.Jobs[1].ApplicationName)
+}
+
+func handlePreviewApplicationSyncSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{
+		Name: "test-application",
+		GitInformation: &model.GitInformation{
+			Provider:         "github",
+			RepositoryOwner:  "test-owner",
+			RepositoryName:   "test-repo",
+			RepositoryBranch: "main",
+		},
+		MonitoringInformation: &model.MonitoringInformation{
+			HasOpenClient:  true,
+			OpenClientPath: "docs/openclient.json",
+		},
+	}
+
+	previewRequest := api.PreviewApplicationSyncRequest{
+		MonitoringInformation: &api.MonitoringInformation{
+			HasOpenClient:  true,
+			OpenClientPath: "openclient.json",
+		},
+	}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), modelApplication.Name).
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		PreviewApplicationSync(gomock.Any(), modelApplication, &model.ApplicationUpdate{
+			MonitoringInformation: &model.MonitoringInformation{HasOpenClient: true, OpenClientPath: "openclient.json"},
+		}).
+		Return(&model.SyncPreview{
+			Application: modelApplication,
+			CommitSha:   "abc123",
+			Dependencies: &model.DependenciesSyncPreview{
+				Sha:                  "def456",
+				DependenciesToUpsert: []*model.ApplicationDependency{},
+				PendingDependencies:  []*model.PendingApplicationDependency{{Consumer: modelApplication, ProviderName: "service-c"}},
+				DependenciesToDelete: []*model.ApplicationDependency{{Consumer: modelApplication, Provider: &model.Application{Name: "service-b"}}},
+			},
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("POST", "/monitoring/preview/test-application", previewRequest)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.PreviewApplicationSyncResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "abc123", actualResponse.CommitSha)
+	require.Nil(t, actualResponse.OpenAPI)
+	require.Empty(t, actualResponse.Dependencies.DependenciesToUpsert)
+	require.Equal(t, "service-c", actualResponse.Dependencies.PendingDependencies[0].Provider)
+	require.Equal(t, "service-b", actualResponse.Dependencies.DependenciesToDelete[0].Provider)
+}
+
+func handlePreviewApplicationSyncInvalidGitInformation(t *testing.T) {
+	router, mocks := setUp(t)
+
+	previewRequest := api.PreviewApplicationSyncRequest{
+		GitInformation: &api.GitInformation{
+			Provider:        "github",
+			RepositoryOwner: "test-owner",
+			RepositoryName:  "test-repo",
+		},
+	}
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("POST", "/monitoring/preview/test-application", previewRequest)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func handleGetSentinelWorkersSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	startedAt := time.Now().Add(-2 * time.Minute).UTC().Truncate(time.Second)
+	deadline := startedAt.Add(time.Minute)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetSentinelWorkers(gomock.Any()).
+		Return(&model.SentinelWorkers{
+			QueueDepth: 4,
+			Workers: []*model.SentinelWorker{
+				{ID: 0, Job: &model.SyncJob{ID: 12, Application: &model.Application{Name: "test-application"}}, StartedAt: &startedAt, Deadline: &deadline, Stuck: true},
+				{ID: 1},
+			},
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/admin/monitoring/sentinel/workers", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetSentinelWorkersResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, int64(4), actualResponse.QueueDepth)
+	require.Len(t, actualResponse.Workers, 2)
+	require.Equal(t, model.SentinelWorkerStatusStuck, actualResponse.Workers[0].Status)
+	require.Equal(t, "test-application", actualResponse.Workers[0].ApplicationName)
+	require.Equal(t, uint(12), *actualResponse.Workers[0].JobID)
+	require.GreaterOrEqual(t, actualResponse.Workers[0].RunningForMs, int64(2*time.Minute/time.Millisecond))
+	require.Equal(t, model.SentinelWorkerStatusIdle, actualResponse.Workers[1].Status)
+	require.Nil(t, actualResponse.Workers[1].JobID)
+}
+
+func handleGetApplicationOpenAPISpecificationVersionsAtTime(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "test-application"}
+	at := time.Date(2025, 1, 7, 12, 0, 0, 0, time.UTC)
+	ingestedAt := at.Add(-time.Hour)
+
+	versionsPage := &model.ApplicationOpenAPIVersionsPage{
+		Versions: []*model.ApplicationOpenAPIVersion{
+			{ID: 4, OpenAPISha: "def456", OpenAPIVersion: "3.1.0", Commit: &model.GitCommit{Sha: "0123456", Message: "Add orders endpoint"}, IngestedAt: ingestedAt},
+		},
+		Page:     1,
+		PageSize: 1,
+		Total:    3,
+	}
+
+	expectedResponse := api.GetApplicationOpenAPISpecificationVersionsResponse{
+		ApplicationName: "test-application",
+		Versions: []*api.ApplicationOpenAPISpecificationVersion{
+			{ID: 4, OpenAPISha: "def456", OpenAPIVersion: "3.1.0", Commit: &api.GitCommit{Sha: "0123456", Message: "Add orders endpoint"}, IngestedAt: ingestedAt},
+		},
+		Page:     1,
+		PageSize: 1,
+		Total:    3,
+	}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "test-application").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetApplicationOpenAPISpecificationVersions(gomock.Any(), modelApplication, &at, 1, 1).
+		Return(versionsPage, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/openapi/test-application/versions?pageSize=1&at=2025-01-07T12:00:00Z", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetApplicationOpenAPISpecificationVersionsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, expectedResponse, actualResponse)
+}
+
+func handleGetApplicationOpenAPISpecificationVersionsInvalidAt(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/openapi/test-application/versions?at=last-tuesday", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func handleUpdateApplicationOpenAPIRetentionSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "test-application"}
+	maxVersions := 10
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "test-application").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		UpdateApplicationOpenAPIRetention(gomock.Any(), modelApplication, &model.OpenAPIRetention{MaxVersions: &maxVersions}).
+		Return(nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("PUT", "/monitoring/openapi/test-application/retention", api.UpdateOpenAPIRetentionRequest{MaxVersions: &maxVersions})
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func handleUpdateApplicationOpenAPIRetentionInvalid(t *testing.T) {
+	router, mocks := setUp(t)
+
+	maxAgeDays := -1
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("PUT", "/monitoring/openapi/test-application/retention", api.UpdateOpenAPIRetentionRequest{MaxAgeDays: &maxAgeDays})
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func handleGetApplicationOpenAPISpecificationDiffSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "test-application"}
+	baseVersionID := uint(4)
+	base := &model.OpenAPISpecificationReference{VersionID: &baseVersionID}
+	revision := &model.OpenAPISpecificationReference{GitRef: "main"}
+
+	diff := &model.OpenAPIDiff{
+		Application: modelApplication,
+		Base:        base,
+		Revision:    revision,
+		Changes: []*model.OpenAPIChange{
+			{ID: "api-path-removed-without-deprecation", Level: "error", Operation: "GET", Path: "/orders", Message: "api path removed without deprecation", AffectedConsumers: []string{"service-b"}},
+			{ID: "api-path-removed-without-deprecation", Level: "error", Operation: "GET", Path: "/invoices", Message: "api path removed without deprecation"},
+		},
+	}
+
+	expectedResponse := api.GetOpenAPISpecificationDiffResponse{
+		ApplicationName: "test-application",
+		Base:            &api.OpenAPISpecificationReference{VersionID: &baseVersionID},
+		Revision:        &api.OpenAPISpecificationReference{GitRef: "main"},
+		Changes: []*api.OpenAPIDiffChange{
+			{ID: "api-path-removed-without-deprecation", Level: "error", Operation: "GET", Path: "/orders", Message: "api path removed without deprecation", AffectsConsumers: true, AffectedConsumers: []string{"service-b"}},
+			{ID: "api-path-removed-without-deprecation", Level: "error", Operation: "GET", Path: "/invoices", Message: "api path removed without deprecation"},
+		},
+	}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "test-application").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		CompareApplicationOpenAPISpecifications(gomock.Any(), modelApplication, base, revision, "").
+		Return(diff, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/openapi/test-application/diff?baseVersion=4&revisionRef=main", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetOpenAPISpecificationDiffResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, expectedResponse, actualResponse)
+}
+
+func handleGetApplicationOpenAPISpecificationDiffAmbiguousReference(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/openapi/test-application/diff?baseVersion=4&baseRef=main&revisionRef=main", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.ErrorResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Equal(t, "either baseVersion or baseRef must be set", actualResponse.Error)
+}
+
+func handleCheckApplicationOpenAPISpecificationSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "test-application"}
+	candidateSpec := `{"openapi": "3.0.0", "info": {"title": "test", "version": "1.0.0"}, "paths": {}}`
+
+	check := &model.OpenAPICheck{
+		Application:            modelApplication,
+		HasStoredSpecification: true,
+		FailOn:                 model.OpenAPIChangeLevelWarning,
+		Passed:                 false,
+		Changes: []*model.OpenAPIChange{
+			{ID: "api-path-removed-without-deprecation", Level: "error", Operation: "GET", Path: "/orders", Message: "api path removed without deprecation", AffectedConsumers: []string{"service-b"}},
+		},
+		IgnoredChangesCount: 2,
+	}
+
+	expectedResponse := api.CheckOpenAPISpecificationResponse{
+		ApplicationName:        "test-application",
+		Passed:                 false,
+		FailOn:                 model.OpenAPIChangeLevelWarning,
+		HasStoredSpecification: true,
+		Changes: []*api.OpenAPIDiffChange{
+			{ID: "api-path-removed-without-deprecation", Level: "error", Operation: "GET", Path: "/orders", Message: "api path removed without deprecation", AffectsConsumers: true, AffectedConsumers: []string{"service-b"}},
+		},
+		IgnoredChangesCount: 2,
+	}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "test-application").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		CheckApplicationOpenAPISpecification(gomock.Any(), modelApplication, candidateSpec, model.OpenAPIChangeLevelWarning).
+		Return(check, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("POST", "/monitoring/openapi/test-application/check", api.CheckOpenAPISpecificationRequest{OpenAPISpec: candidateSpec, FailOn: model.OpenAPIChangeLevelWarning})
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.CheckOpenAPISpecificationResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, expectedResponse, actualResponse)
+}
+
+func handleCheckApplicationOpenAPISpecificationMissingSpecification(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("POST", "/monitoring/openapi/test-application/check", api.CheckOpenAPISpecificationRequest{FailOn: model.OpenAPIChangeLevelError})
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func handleValidateApplicationOpenClientSpecificationSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "test-application"}
+	candidateSpec := `{"dependencies": {"service-b": {"endpoints": {"/orders": {"get": {}}}}}}`
+
+	openClientValidation := &model.OpenClientValidation{
+		Application: modelApplication,
+		Valid:       true,
+		Errors:      []string{},
+		Dependencies: []*model.OpenClientDependencyValidation{
+			{Name: "service-b", Pending: true, Errors: []string{}, Warnings: []string{"application service-b is not registered, the dependency stays pending until it is"}},
+		},
+	}
+
+	expectedResponse := api.ValidateOpenClientSpecificationResponse{
+		ApplicationName: "test-application",
+		Valid:           true,
+		Errors:          []string{},
+		Dependencies: []*api.OpenClientDependencyValidation{
+			{Name: "service-b", Pending: true, Errors: []string{}, Warnings: []string{"application service-b is not registered, the dependency stays pending until it is"}},
+		},
+	}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "test-application").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		ValidateApplicationOpenClientSpecification(gomock.Any(), modelApplication, candidateSpec).
+		Return(openClientValidation, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("POST", "/monitoring/openclient/test-application/validate", api.ValidateOpenClientSpecificationRequest{OpenClientSpec: candidateSpec})
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.ValidateOpenClientSpecificationResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, expectedResponse, actualResponse)
+}
+
+func handleGetApplicationContractDriftsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "test-application"}
+	firstDetectedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "test-application").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetApplicationContractDrifts(gomock.Any(), modelApplication).
+		Return([]*model.ContractDrift{
+			{Consumer: &model.Application{Name: "service-b"}, Provider: modelApplication, Path: "/orders", Method: "DELETE", FirstDetectedAt: firstDetectedAt},
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/drift/test-application", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetContractDriftsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, api.GetContractDriftsResponse{
+		Drifts: []api.ContractDrift{
+			{Consumer: "service-b", Provider: "test-application", Path: "/orders", Method: "DELETE", FirstDetectedAt: firstDetectedAt},
+		},
+	}, actualResponse)
+}
+
+func handleGetContractDriftsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetContractDrifts(gomock.Any()).
+		Return([]*model.ContractDrift{}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/drift", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetContractDriftsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Empty(t, actualResponse.Drifts)
+}
+
+func handleGetProviderOperationsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "orders-service"}
+	filter := model.EndpointConsumerFilter{Method: "DELETE", Path: "/orders/{id}"}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "orders-service").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetProviderOperations(gomock.Any(), modelApplication, filter).
+		Return([]*model.ProviderOperation{
+			{
+				Method: "DELETE",
+				Path:   "/orders/{orderId}",
+				Consumers: []*model.OperationConsumer{
+					{Name: "billing-service", Reasons: []string{"cancel unpaid orders"}},
+					{Name: "support-service"},
+				},
+			},
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/consumers/orders-service?method=DELETE&path=/orders/%7Bid%7D", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetProviderOperationsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, api.GetProviderOperationsResponse{
+		Provider: "orders-service",
+		Operations: []api.ProviderOperation{
+			{
+				Method: "DELETE",
+				Path:   "/orders/{orderId}",
+				Consumers: []api.OperationConsumer{
+					{Name: "billing-service", Reasons: []string{"cancel unpaid orders"}},
+					{Name: "support-service", Reasons: []string{}},
+				},
+			},
+		},
+	}, actualResponse)
+}
+
+func handleGetProviderOperationsInvalidMethod(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "orders-service"}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "orders-service").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetProviderOperations(gomock.Any(), modelApplication, model.EndpointConsumerFilter{Method: "FETCH"}).
+		Return(nil, errors.NewBadRequestError("invalid HTTP method FETCH"))
+
+	mocks.loggerMock.EXPECT().
+		Errorf(gomock.Any(), gomock.Any())
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/consumers/orders-service?method=FETCH", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func getMockedUnusedOperationsReport(application *model.Application) *model.UnusedOperationsReport {
+	return &model.UnusedOperationsReport{
+		Application:            application,
+		HasStoredSpecification: true,
+		UnusedOperations: []*model.UnusedOperation{
+			{Method: "POST", Path: "/orders", OperationID: "createOrder"},
+		},
+		DeprecatedUnusedOperations: []*model.UnusedOperation{
+			{Method: "DELETE", Path: "/orders/{id}", Summary: "Cancel an order", Deprecated: true},
+		},
+	}
+}
+
+func handleGetApplicationUnusedOperationsSuccess(t *testing.T) {
+	router, mocks := setUp(t)
+
+	modelApplication := &model.Application{Name: "orders-service"}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplication(gomock.Any(), "orders-service").
+		Return(modelApplication, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetApplicationUnusedOperations(gomock.Any(), modelApplication).
+		Return(getMockedUnusedOperationsReport(modelApplication), nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/unused/orders-service", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	actualResponse := api.GetUnusedOperationsResponse{}
+	err = json.NewDecoder(recorder.Body).Decode(&actualResponse)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, api.GetUnusedOperationsResponse{
+		ApplicationName:            "orders-service",
+		HasStoredSpecification:     true,
+		UnusedOperations:           []api.UnusedOperation{{Method: "POST", Path: "/orders", OperationID: "createOrder"}},
+		DeprecatedUnusedOperations: []api.UnusedOperation{{Method: "DELETE", Path: "/orders/{id}", Summary: "Cancel an order", Deprecated: true}},
+	}, actualResponse)
+}
+
+func handleGetTeamUnusedOperationsCSV(t *testing.T) {
+	router, mocks := setUp(t)
+
+	applications := []*model.Application{{Name: "orders-service"}, {Name: "users-service"}}
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplicationsByTeam(gomock.Any(), "team-a").
+		Return(applications, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		GetApplicationsUnusedOperations(gomock.Any(), applications).
+		Return([]*model.UnusedOperationsReport{
+			getMockedUnusedOperationsReport(applications[0]),
+			{Application: applications[1]},
+		}, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/unused/team/team-a?format=csv", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "application,method,path,operation_id,summary,deprecated\n"+
+		"orders-service,POST,/orders,createOrder,,false\n"+
+		"orders-service,DELETE,/orders/{id},,Cancel an order,true\n", recorder.Body.String())
+}
+
+func handleGetApplicationUnusedOperationsInvalidFormat(t *testing.T) {
+	router, mocks := setUp(t)
+
+	mocks.loggerMock.EXPECT().
+		Infow(gomock.Any(), gomock.Any())
+
+	request, recorder, err := test.NewHTTPRequest("GET", "/monitoring/unused/orders-service?format=xml", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -1,17 +1,45 @@
 package monitoring
 
 import (
+	"bytes"
 	"cosmos-server/api"
 	"cosmos-server/pkg/model"
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 type Translator interface {
 	ToGetApplicationsInteractionsResponse(interactions *model.ApplicationsInteractions) *api.GetApplicationsInteractionsResponse
 	ToGetApplicationsInteractionsFilters(teams []string, includeNeighbors bool) model.ApplicationDependencyFilter
 	ToGetOpenAPiSpecificationResponse(openAPISpec *model.ApplicationOpenAPISpecification) (*api.GetApplicationOpenAPISpecificationResponse, error)
+	ToGetOpenAPISpecificationVersionsResponse(application *model.Application, versionsPage *model.ApplicationOpenAPIVersionsPage) *api.GetApplicationOpenAPISpecificationVersionsResponse
+	ToGetOpenAPISpecificationVersionResponse(application *model.Application, version *model.ApplicationOpenAPIVersion) (*api.GetApplicationOpenAPISpecificationVersionResponse, error)
+	ToGetOpenAPISpecificationDiffResponse(diff *model.OpenAPIDiff) *api.GetOpenAPISpecificationDiffResponse
+	ToCheckOpenAPISpecificationResponse(check *model.OpenAPICheck) *api.CheckOpenAPISpecificationResponse
+	ToGetProviderOperationsResponse(provider *model.Application, operations []*model.ProviderOperation) *api.GetProviderOperationsResponse
+	ToGetUnusedOperationsResponse(report *model.UnusedOperationsReport) *api.GetUnusedOperationsResponse
+	ToGetTeamUnusedOperationsResponse(team string, reports []*model.UnusedOperationsReport) *api.GetTeamUnusedOperationsResponse
+	ToUnusedOperationsCSV(reports []*model.UnusedOperationsReport) ([]byte, error)
+	ToGetContractDriftsResponse(drifts []*model.ContractDrift) *api.GetContractDriftsResponse
+	ToValidateOpenClientSpecificationResponse(openClientValidation *model.OpenClientValidation) *api.ValidateOpenClientSpecificationResponse
+	ToOpenAPIRetentionModel(updateRetentionRequest *api.UpdateOpenAPIRetentionRequest) *model.OpenAPIRetention
+	ToGetOpenAPIRetentionResponse(retention *model.OpenAPIRetention) *api.GetOpenAPIRetentionResponse
 	ToGetCompleteApplicationMonitoringResponse(application *model.Application, interactions *model.ApplicationsInteractions, openAPISpec *model.ApplicationOpenAPISpecification) (*api.GetCompleteApplicationMonitoringResponse, error)
 	ToSentinelSettingsUpdateModel(updateSettingsApi *api.UpdateSentinelSettingsRequest) *model.SentinelSettingsUpdate
 	ToGetSentinelSettingsResponse(sentinelSettingsModel *model.SentinelSettings) *api.GetSentinelSettingsResponse
+	ToGetSentinelRunsResponse(runsPage *model.SentinelRunsPage) *api.GetSentinelRunsResponse
+	ToGetSentinelRunResponse(run *model.SentinelRun) *api.GetSentinelRunResponse
+	ToGetGitRateLimitsResponse(rateLimits []*model.GitRateLimit) *api.GetGitRateLimitsResponse
+	ToSyncApplicationResponse(job *model.SyncJob) *api.SyncApplicationResponse
+	ToSyncAllApplicationsResponse(jobs []*model.SyncJob) *api.SyncAllApplicationsResponse
+	ToGetSyncJobResponse(job *model.SyncJob) *api.GetSyncJobResponse
+	ToSyncPreviewChangesModel(previewRequest *api.PreviewApplicationSyncRequest) *model.ApplicationUpdate
+	ToPreviewApplicationSyncResponse(preview *model.SyncPreview) *api.PreviewApplicationSyncResponse
+	ToGetSentinelWorkersResponse(workers *model.SentinelWorkers) *api.GetSentinelWorkersResponse
 }
 
 type translator struct{}
@@ -128,6 +156,7 @@ func (t *translator) ToGetOpenAPiSpecificationResponse(modelOpenAPISpec *model.A
 
 	return &api.GetApplicationOpenAPISpecificationResponse{
 		ApplicationName: applicationName,
+		OpenAPIVersion:  modelOpenAPISpec.OpenAPIVersion,
 		OpenAPISpec:     string(marshalledOpenAPISpec),
 	}, nil
 }
@@ -145,6 +174,308 @@ func (t *translator) toMarshalledOpenAPISpec(openAPISpec *model.ApplicationOpenA
 	return string(marshalledOpenAPISpec), nil
 }
 
+func (t *translator) ToGetOpenAPISpecificationVersionsResponse(application *model.Application, versionsPage *model.ApplicationOpenAPIVersionsPage) *api.GetApplicationOpenAPISpecificationVersionsResponse {
+	if versionsPage == nil {
+		return nil
+	}
+
+	versions := make([]*api.ApplicationOpenAPISpecificationVersion, 0, len(versionsPage.Versions))
+	for _, version := range versionsPage.Versions {
+		versions = append(versions, t.toOpenAPISpecificationVersion(version))
+	}
+
+	return &api.GetApplicationOpenAPISpecificationVersionsResponse{
+		ApplicationName: application.Name,
+		Versions:        versions,
+		Page:            versionsPage.Page,
+		PageSize:        versionsPage.PageSize,
+		Total:           versionsPage.Total,
+	}
+}
+
+func (t *translator) ToGetOpenAPISpecificationVersionResponse(application *model.Application, version *model.ApplicationOpenAPIVersion) (*api.GetApplicationOpenAPISpecificationVersionResponse, error) {
+	if version == nil {
+		return nil, nil
+	}
+
+	marshalledOpenAPISpec, err := t.toMarshalledOpenAPISpec(&model.ApplicationOpenAPISpecification{OpenAPISpec: version.OpenAPISpec})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GetApplicationOpenAPISpecificationVersionResponse{
+		ApplicationName: application.Name,
+		Version:         t.toOpenAPISpecificationVersion(version),
+		OpenAPISpec:     marshalledOpenAPISpec,
+	}, nil
+}
+
+func (t *translator) toOpenAPISpecificationVersion(version *model.ApplicationOpenAPIVersion) *api.ApplicationOpenAPISpecificationVersion {
+	apiVersion := &api.ApplicationOpenAPISpecificationVersion{
+		ID:             version.ID,
+		OpenAPISha:     version.OpenAPISha,
+		OpenAPIVersion: version.OpenAPIVersion,
+		IngestedAt:     version.IngestedAt,
+	}
+
+	if version.Commit != nil {
+		apiVersion.Commit = &api.GitCommit{
+			Sha:         version.Commit.Sha,
+			Message:     version.Commit.Message,
+			AuthorName:  version.Commit.AuthorName,
+			AuthorEmail: version.Commit.AuthorEmail,
+			Date:        version.Commit.Date,
+		}
+	}
+
+	return apiVersion
+}
+
+func (t *translator) ToGetOpenAPISpecificationDiffResponse(diff *model.OpenAPIDiff) *api.GetOpenAPISpecificationDiffResponse {
+	if diff == nil {
+		return nil
+	}
+
+	applicationName := ""
+	if diff.Application != nil {
+		applicationName = diff.Application.Name
+	}
+
+	return &api.GetOpenAPISpecificationDiffResponse{
+		ApplicationName: applicationName,
+		Base:            t.toOpenAPISpecificationReference(diff.Base),
+		Revision:        t.toOpenAPISpecificationReference(diff.Revision),
+		Changes:         t.toOpenAPIDiffChanges(diff.Changes),
+		Format:          diff.Format,
+		Rendered:        diff.Rendered,
+	}
+}
+
+func (t *translator) ToCheckOpenAPISpecificationResponse(check *model.OpenAPICheck) *api.CheckOpenAPISpecificationResponse {
+	if check == nil {
+		return nil
+	}
+
+	applicationName := ""
+	if check.Application != nil {
+		applicationName = check.Application.Name
+	}
+
+	return &api.CheckOpenAPISpecificationResponse{
+		ApplicationName:        applicationName,
+		Passed:                 check.Passed,
+		FailOn:                 check.FailOn,
+		HasStoredSpecification: check.HasStoredSpecification,
+		Changes:                t.toOpenAPIDiffChanges(check.Changes),
+		IgnoredChangesCount:    check.IgnoredChangesCount,
+	}
+}
+
+func (t *translator) ToValidateOpenClientSpecificationResponse(openClientValidation *model.OpenClientValidation) *api.ValidateOpenClientSpecificationResponse {
+	if openClientValidation == nil {
+		return nil
+	}
+
+	applicationName := ""
+	if openClientValidation.Application != nil {
+		applicationName = openClientValidation.Application.Name
+	}
+
+	dependencies := make([]*api.OpenClientDependencyValidation, 0, len(openClientValidation.Dependencies))
+	for _, dependency := range openClientValidation.Dependencies {
+		dependencies = append(dependencies, &api.OpenClientDependencyValidation{
+			Name:     dependency.Name,
+			Pending:  dependency.Pending,
+			Errors:   dependency.Errors,
+			Warnings: dependency.Warnings,
+		})
+	}
+
+	return &api.ValidateOpenClientSpecificationResponse{
+		ApplicationName: applicationName,
+		Valid:           openClientValidation.Valid,
+		Errors:          openClientValidation.Errors,
+		Dependencies:    dependencies,
+	}
+}
+
+func (t *translator) ToGetContractDriftsResponse(drifts []*model.ContractDrift) *api.GetContractDriftsResponse {
+	apiDrifts := make([]api.ContractDrift, 0, len(drifts))
+	for _, drift := range drifts {
+		apiDrift := api.ContractDrift{
+			Path:            drift.Path,
+			Method:          drift.Method,
+			FirstDetectedAt: drift.FirstDetectedAt,
+		}
+		if drift.Consumer != nil {
+			apiDrift.Consumer = drift.Consumer.Name
+		}
+		if drift.Provider != nil {
+			apiDrift.Provider = drift.Provider.Name
+		}
+		apiDrifts = append(apiDrifts, apiDrift)
+	}
+
+	return &api.GetContractDriftsResponse{
+		Drifts: apiDrifts,
+	}
+}
+
+func (t *translator) ToGetProviderOperationsResponse(provider *model.Application, operations []*model.ProviderOperation) *api.GetProviderOperationsResponse {
+	apiOperations := make([]api.ProviderOperation, 0, len(operations))
+	for _, operation := range operations {
+		consumers := make([]api.OperationConsumer, 0, len(operation.Consumers))
+		for _, consumer := range operation.Consumers {
+			reasons := consumer.Reasons
+			if reasons == nil {
+				reasons = []string{}
+			}
+			consumers = append(consumers, api.OperationConsumer{
+				Name:    consumer.Name,
+				Reasons: reasons,
+			})
+		}
+
+		apiOperations = append(apiOperations, api.ProviderOperation{
+			Method:    operation.Method,
+			Path:      operation.Path,
+			Consumers: consumers,
+		})
+	}
+
+	return &api.GetProviderOperationsResponse{
+		Provider:   provider.Name,
+		Operations: apiOperations,
+	}
+}
+
+func (t *translator) ToGetUnusedOperationsResponse(report *model.UnusedOperationsReport) *api.GetUnusedOperationsResponse {
+	if report == nil {
+		return nil
+	}
+
+	applicationName := ""
+	if report.Application != nil {
+		applicationName = report.Application.Name
+	}
+
+	return &api.GetUnusedOperationsResponse{
+		ApplicationName:            applicationName,
+		HasStoredSpecification:     report.HasStoredSpecification,
+		UnusedOperations:           t.toUnusedOperations(report.UnusedOperations),
+		DeprecatedUnusedOperations: t.toUnusedOperations(report.DeprecatedUnusedOperations),
+	}
+}
+
+func (t *translator) ToGetTeamUnusedOperationsResponse(team string, reports []*model.UnusedOperationsReport) *api.GetTeamUnusedOperationsResponse {
+	applications := make([]api.GetUnusedOperationsResponse, 0, len(reports))
+	for _, report := range reports {
+		applications = append(applications, *t.ToGetUnusedOperationsResponse(report))
+	}
+
+	return &api.GetTeamUnusedOperationsResponse{
+		Team:         team,
+		Applications: applications,
+	}
+}
+
+func (t *translator) toUnusedOperations(modelOperations []*model.UnusedOperation) []api.UnusedOperation {
+	operations := make([]api.UnusedOperation, 0, len(modelOperations))
+	for _, operation := range modelOperations {
+		operations = append(operations, api.UnusedOperation{
+			Method:      operation.Method,
+			Path:        operation.Path,
+			OperationID: operation.OperationID,
+			Summary:     operation.Summary,
+			Deprecated:  operation.Deprecated,
+		})
+	}
+	return operations
+}
+
+// ToUnusedOperationsCSV writes one row per unused operation, the applications without a stored specification have none
+func (t *translator) ToUnusedOperationsCSV(reports []*model.UnusedOperationsReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	if err := writer.Write([]string{"application", "method", "path", "operation_id", "summary", "deprecated"}); err != nil {
+		return nil, err
+	}
+
+	for _, report := range reports {
+		applicationName := ""
+		if report.Application != nil {
+			applicationName = report.Application.Name
+		}
+
+		for _, operations := range [][]*model.UnusedOperation{report.UnusedOperations, report.DeprecatedUnusedOperations} {
+			for _, operation := range operations {
+				row := []string{applicationName, operation.Method, operation.Path, operation.OperationID, operation.Summary, strconv.FormatBool(operation.Deprecated)}
+				if err := writer.Write(row); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (t *translator) toOpenAPIDiffChanges(modelChanges []*model.OpenAPIChange) []*api.OpenAPIDiffChange {
+	changes := make([]*api.OpenAPIDiffChange, 0, len(modelChanges))
+	for _, change := range modelChanges {
+		changes = append(changes, &api.OpenAPIDiffChange{
+			ID:                change.ID,
+			Level:             change.Level,
+			Operation:         change.Operation,
+			Path:              change.Path,
+			Message:           change.Message,
+			AffectsConsumers:  len(change.AffectedConsumers) > 0,
+			AffectedConsumers: change.AffectedConsumers,
+		})
+	}
+
+	return changes
+}
+
+func (t *translator) toOpenAPISpecificationReference(reference *model.OpenAPISpecificationReference) *api.OpenAPISpecificationReference {
+	if reference == nil {
+		return nil
+	}
+
+	return &api.OpenAPISpecificationReference{
+		VersionID: reference.VersionID,
+		GitRef:    reference.GitRef,
+	}
+}
+
+func (t *translator) ToOpenAPIRetentionModel(updateRetentionRequest *api.UpdateOpenAPIRetentionRequest) *model.OpenAPIRetention {
+	if updateRetentionRequest == nil {
+		return nil
+	}
+
+	return &model.OpenAPIRetention{
+		MaxVersions: updateRetentionRequest.MaxVersions,
+		MaxAgeDays:  updateRetentionRequest.MaxAgeDays,
+	}
+}
+
+func (t *translator) ToGetOpenAPIRetentionResponse(retention *model.OpenAPIRetention) *api.GetOpenAPIRetentionResponse {
+	if retention == nil {
+		return nil
+	}
+
+	return &api.GetOpenAPIRetentionResponse{
+		MaxVersions: retention.MaxVersions,
+		MaxAgeDays:  retention.MaxAgeDays,
+	}
+}
+
 func (t *translator) ToGetCompleteApplicationMonitoringResponse(application *model.Application, interactions *model.ApplicationsInteractions, openAPISpec *model.ApplicationOpenAPISpecification) (*api.GetCompleteApplicationMonitoringResponse, error) {
 	if application == nil {
 		return nil, nil
@@ -157,17 +488,27 @@ func (t *translator) ToGetCompleteApplicationMonitoringResponse(application *mod
 		return nil, err
 	}
 
-	dependencies, consumedEndpoints := t.toDependenciesAndConsumedEndpoints(interactions, application.Name)
+	var spec *openapi3.T
+	openAPIVersion := ""
+	if openAPISpec != nil {
+		spec = openAPISpec.OpenAPISpec
+		openAPIVersion = openAPISpec.OpenAPIVersion
+	}
+
+	dependencies, consumedEndpoints := t.toDependenciesAndConsumedEndpoints(interactions, application.Name, model.NewOperationMatcher(spec))
 
 	return &api.GetCompleteApplicationMonitoringResponse{
 		Application:       *applicationApi,
 		OpenAPISpec:       marshalledOpenAPISpec,
+		OpenAPIVersion:    openAPIVersion,
 		Dependencies:      dependencies,
 		ConsumedEndpoints: consumedEndpoints,
 	}, nil
 }
 
-func (t *translator) toDependenciesAndConsumedEndpoints(interactions *model.ApplicationsInteractions, applicationName string) ([]api.ApplicationDependency, api.ConsumedEndpoints) {
+// toDependenciesAndConsumedEndpoints keys the consumed endpoints by the paths of the specification of the application,
+// the declared path being kept when the specification has no matching path
+func (t *translator) toDependenciesAndConsumedEndpoints(interactions *model.ApplicationsInteractions, applicationName string, matcher *model.OperationMatcher) ([]api.ApplicationDependency, api.ConsumedEndpoints) {
 	if interactions == nil {
 		return nil, nil
 	}
@@ -179,7 +520,12 @@ func (t *translator) toDependenciesAndConsumedEndpoints(interactions *model.Appl
 		if interaction.Consumer.Name == applicationName {
 			dependencies = append(dependencies, t.toApplicationDependency(interaction))
 		} else if interaction.Provider.Name == applicationName {
-			for path, methods := range interaction.Endpoints {
+			for declaredPath, methods := range interaction.Endpoints {
+				path := declaredPath
+				if specPath, pathItem := matcher.FindPath(declaredPath); pathItem != nil {
+					path = specPath
+				}
+
 				if _, exists := consumedEndpoints[path]; !exists {
 					consumedEndpoints[path] = make(api.ConsumedEndpointMethods)
 				}
@@ -190,6 +536,9 @@ func (t *translator) toDependenciesAndConsumedEndpoints(interactions *model.Appl
 						}
 					}
 					currentConsumedEndpoints := consumedEndpoints[path][method]
+					if slices.Contains(currentConsumedEndpoints.Consumers, interaction.Consumer.Name) {
+						continue
+					}
 					currentConsumedEndpoints.Consumers = append(currentConsumedEndpoints.Consumers, interaction.Consumer.Name)
 					consumedEndpoints[path][method] = currentConsumedEndpoints
 				}
@@ -208,6 +557,24 @@ func (t *translator) ToApplicationApi(applicationModel *model.Application) *api.
 		GitInformation:        t.ToApiGitInformation(applicationModel.GitInformation),
 		MonitoringInformation: t.ToMonitoringInformationApi(applicationModel.MonitoringInformation),
 		Token:                 t.ToTokenApi(applicationModel.Token),
+		SyncStatus:            t.ToSyncStatusApi(applicationModel.SyncStatus),
+	}
+}
+
+func (t *translator) ToSyncStatusApi(syncStatus *model.SyncStatus) *api.SyncStatus {
+	if syncStatus == nil {
+		return nil
+	}
+
+	return &api.SyncStatus{
+		LastAttemptAt:                syncStatus.LastAttemptAt,
+		LastSuccessAt:                syncStatus.LastSuccessAt,
+		LastError:                    syncStatus.LastError,
+		LastErrorCategory:            syncStatus.LastErrorCategory,
+		ConsecutiveFailures:          syncStatus.ConsecutiveFailures,
+		ConsecutivePermanentFailures: syncStatus.ConsecutivePermanentFailures,
+		Quarantined:                  syncStatus.QuarantinedAt != nil,
+		QuarantinedAt:                syncStatus.QuarantinedAt,
 	}
 }
 
@@ -254,11 +621,15 @@ func (t *translator) ToMonitoringInformationApi(monitoringInfo *model.Monitoring
 		return nil
 	}
 
+	syncEnabled := monitoringInfo.SyncEnabled
+
 	return &api.MonitoringInformation{
 		HasOpenAPI:     monitoringInfo.HasOpenApi,
 		OpenAPIPath:    monitoringInfo.OpenApiPath,
 		HasOpenClient:  monitoringInfo.HasOpenClient,
 		OpenClientPath: monitoringInfo.OpenClientPath,
+		SyncInterval:   monitoringInfo.SyncInterval,
+		SyncEnabled:    &syncEnabled,
 	}
 }
 
@@ -283,3 +654,253 @@ func (t *translator) ToGetSentinelSettingsResponse(sentinelSettingsModel *model.
 		Interval: sentinelSettingsModel.Interval,
 	}
 }
+
+func (t *translator) ToGetSentinelRunsResponse(runsPage *model.SentinelRunsPage) *api.GetSentinelRunsResponse {
+	if runsPage == nil {
+		return nil
+	}
+
+	runs := make([]*api.SentinelRunSummary, 0, len(runsPage.Runs))
+	for _, run := range runsPage.Runs {
+		failedApplicationsCount := 0
+		for _, runApplication := range run.Applications {
+			if runApplication.Status == model.SyncStatusFailed {
+				failedApplicationsCount++
+			}
+		}
+
+		runs = append(runs, &api.SentinelRunSummary{
+			ID:                      run.ID,
+			Trigger:                 run.Trigger,
+			StartedAt:               run.StartedAt,
+			FinishedAt:              run.FinishedAt,
+			ApplicationsCount:       len(run.Applications),
+			FailedApplicationsCount: failedApplicationsCount,
+		})
+	}
+
+	return &api.GetSentinelRunsResponse{
+		Runs:     runs,
+		Page:     runsPage.Page,
+		PageSize: runsPage.PageSize,
+		Total:    runsPage.Total,
+	}
+}
+
+func (t *translator) ToGetSentinelRunResponse(run *model.SentinelRun) *api.GetSentinelRunResponse {
+	if run == nil {
+		return nil
+	}
+
+	applications := make([]*api.SentinelRunApplication, 0, len(run.Applications))
+	for _, runApplication := range run.Applications {
+		applications = append(applications, &api.SentinelRunApplication{
+			ApplicationName: runApplication.ApplicationName,
+			Status:          runApplication.Status,
+			DependenciesSha: runApplication.DependenciesSha,
+			OpenAPISha:      runApplication.OpenAPISha,
+			DurationMs:      runApplication.Duration.Milliseconds(),
+			Error:           runApplication.Error,
+		})
+	}
+
+	return &api.GetSentinelRunResponse{
+		Run: &api.SentinelRun{
+			ID:           run.ID,
+			Trigger:      run.Trigger,
+			StartedAt:    run.StartedAt,
+			FinishedAt:   run.FinishedAt,
+			Applications: applications,
+		},
+	}
+}
+
+func (t *translator) ToGetGitRateLimitsResponse(rateLimits []*model.GitRateLimit) *api.GetGitRateLimitsResponse {
+	now := time.Now()
+
+	apiRateLimits := make([]*api.GitRateLimit, 0, len(rateLimits))
+	for _, rateLimit := range rateLimits {
+		apiRateLimits = append(apiRateLimits, &api.GitRateLimit{
+			Provider:  rateLimit.Provider,
+			Token:     rateLimit.Token,
+			Limit:     rateLimit.Limit,
+			Remaining: rateLimit.Remaining,
+			Used:      rateLimit.Used,
+			ResetAt:   rateLimit.ResetAt,
+			Paused:    rateLimit.Exhausted(now),
+			UpdatedAt: rateLimit.UpdatedAt,
+		})
+	}
+
+	return &api.GetGitRateLimitsResponse{RateLimits: apiRateLimits}
+}
+
+func (t *translator) ToGetSentinelWorkersResponse(workers *model.SentinelWorkers) *api.GetSentinelWorkersResponse {
+	now := time.Now()
+
+	apiWorkers := make([]*api.SentinelWorker, 0, len(workers.Workers))
+	for _, worker := range workers.Workers {
+		apiWorker := &api.SentinelWorker{
+			ID:     worker.ID,
+			Status: worker.Status(),
+		}
+		if worker.Job != nil {
+			jobID := worker.Job.ID
+			apiWorker.JobID = &jobID
+			if worker.Job.Application != nil {
+				apiWorker.ApplicationName = worker.Job.Application.Name
+			}
+			apiWorker.StartedAt = worker.StartedAt
+			apiWorker.Deadline = worker.Deadline
+			if worker.StartedAt != nil {
+				apiWorker.RunningForMs = now.Sub(*worker.StartedAt).Milliseconds()
+			}
+		}
+		apiWorkers = append(apiWorkers, apiWorker)
+	}
+
+	return &api.GetSentinelWorkersResponse{
+		QueueDepth: workers.QueueDepth,
+		Workers:    apiWorkers,
+	}
+}
+
+func (t *translator) ToSyncApplicationResponse(job *model.SyncJob) *api.SyncApplicationResponse {
+	return &api.SyncApplicationResponse{
+		Job: t.toSyncJob(job),
+	}
+}
+
+func (t *translator) ToSyncAllApplicationsResponse(jobs []*model.SyncJob) *api.SyncAllApplicationsResponse {
+	apiJobs := make([]*api.SyncJob, 0, len(jobs))
+	for _, job := range jobs {
+		apiJobs = append(apiJobs, t.toSyncJob(job))
+	}
+
+	return &api.SyncAllApplicationsResponse{
+		Jobs: apiJobs,
+	}
+}
+
+func (t *translator) ToGetSyncJobResponse(job *model.SyncJob) *api.GetSyncJobResponse {
+	return &api.GetSyncJobResponse{
+		Job: t.toSyncJob(job),
+	}
+}
+
+func (t *translator) toSyncJob(job *model.SyncJob) *api.SyncJob {
+	if job == nil {
+		return nil
+	}
+
+	applicationName := ""
+	if job.Application != nil {
+		applicationName = job.Application.Name
+	}
+
+	return &api.SyncJob{
+		ID:              job.ID,
+		ApplicationName: applicationName,
+		RunID:           job.RunID,
+		Trigger:         job.Trigger,
+		Status:          job.Status,
+		Attempts:        job.Attempts,
+		AvailableAt:     job.AvailableAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		Error:           job.Error,
+	}
+}
+
+func (t *translator) ToSyncPreviewChangesModel(previewRequest *api.PreviewApplicationSyncRequest) *model.ApplicationUpdate {
+	changes := &model.ApplicationUpdate{
+		TokenName: previewRequest.TokenName,
+	}
+
+	if previewRequest.GitInformation != nil {
+		changes.GitInformation = &model.GitInformation{
+			Provider:         previewRequest.GitInformation.Provider,
+			RepositoryOwner:  previewRequest.GitInformation.RepositoryOwner,
+			RepositoryName:   previewRequest.GitInformation.RepositoryName,
+			RepositoryBranch: previewRequest.GitInformation.RepositoryBranch,
+		}
+	}
+
+	if previewRequest.MonitoringInformation != nil {
+		changes.MonitoringInformation = &model.MonitoringInformation{
+			HasOpenApi:     previewRequest.MonitoringInformation.HasOpenAPI,
+			OpenApiPath:    previewRequest.MonitoringInformation.OpenAPIPath,
+			HasOpenClient:  previewRequest.MonitoringInformation.HasOpenClient,
+			OpenClientPath: previewRequest.MonitoringInformation.OpenClientPath,
+		}
+	}
+
+	return changes
+}
+
+func (t *translator) ToPreviewApplicationSyncResponse(preview *model.SyncPreview) *api.PreviewApplicationSyncResponse {
+	response := &api.PreviewApplicationSyncResponse{
+		ApplicationName: preview.Application.Name,
+		CommitSha:       preview.CommitSha,
+	}
+
+	if preview.Dependencies != nil {
+		response.Dependencies = &api.DependenciesSyncPreview{
+			DependenciesSha:      preview.Dependencies.Sha,
+			DependenciesToUpsert: t.toApplicationDependencySlice(preview.Dependencies.DependenciesToUpsert),
+			PendingDependencies:  t.toPendingApplicationDependencySlice(preview.Dependencies.PendingDependencies),
+			DependenciesToDelete: t.toApplicationDependencySlice(preview.Dependencies.DependenciesToDelete),
+		}
+		if preview.Dependencies.Err != nil {
+			response.Dependencies.Error = preview.Dependencies.Err.Error()
+		}
+	}
+
+	if preview.OpenAPI != nil {
+		response.OpenAPI = &api.OpenAPISyncPreview{
+			OpenAPISha:             preview.OpenAPI.Sha,
+			HasStoredSpecification: preview.OpenAPI.HasStoredSpecification,
+			Changes:                t.toOpenAPIChanges(preview.OpenAPI.Changes),
+		}
+		if preview.OpenAPI.Err != nil {
+			response.OpenAPI.Error = preview.OpenAPI.Err.Error()
+		}
+	}
+
+	return response
+}
+
+func (t *translator) toPendingApplicationDependencySlice(pendingDependencies []*model.PendingApplicationDependency) []api.PendingApplicationDependency {
+	if pendingDependencies == nil {
+		return nil
+	}
+
+	result := make([]api.PendingApplicationDependency, 0, len(pendingDependencies))
+	for _, pendingDependency := range pendingDependencies {
+		result = append(result, api.PendingApplicationDependency{
+			Consumer:  pendingDependency.Consumer.Name,
+			Provider:  pendingDependency.ProviderName,
+			Reasons:   pendingDependency.Reasons,
+			Endpoints: t.toDependencyEndpointsMap(pendingDependency.Endpoints),
+		})
+	}
+	return result
+}
+
+func (t *translator) toOpenAPIChanges(changes []*model.OpenAPIChange) []*api.OpenAPIChange {
+	if changes == nil {
+		return nil
+	}
+
+	result := make([]*api.OpenAPIChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, &api.OpenAPIChange{
+			ID:        change.ID,
+			Level:     change.Level,
+			Operation: change.Operation,
+			Path:      change.Path,
+			Message:   change.Message,
+		})
+	}
+	return result
+}
@@ -10,6 +10,7 @@ import (
 	"cosmos-server/pkg/services/team"
 	"cosmos-server/pkg/services/token"
 	"cosmos-server/pkg/services/user"
+	"cosmos-server/pkg/services/webhook"
 
 	"github.com/gin-gonic/gin"
 
@@ -20,6 +21,7 @@ import (
 	teamRoute "cosmos-server/pkg/routes/team"
 	tokenRoute "cosmos-server/pkg/routes/token"
 	userRoute "cosmos-server/pkg/routes/user"
+	webhookRoute "cosmos-server/pkg/routes/webhook"
 )
 
 type HTTPRoutes struct {
@@ -30,10 +32,11 @@ type HTTPRoutes struct {
 	MonitoringService  monitoring.Service
 	TokenService       token.Service
 	GroupService       group.Service
+	WebhookService     webhook.Service
 	Logger             log.Logger
 }
 
-func NewHTTPRoutes(authService auth.Service, userService user.Service, teamService team.Service, applicationService application.Service, monitoringService monitoring.Service, tokenService token.Service, groupService group.Service, logger log.Logger) *HTTPRoutes {
+func NewHTTPRoutes(authService auth.Service, userService user.Service, teamService team.Service, applicationService application.Service, monitoringService monitoring.Service, tokenService token.Service, groupService group.Service, webhookService webhook.Service, logger log.Logger) *HTTPRoutes {
 	return &HTTPRoutes{
 		AuthService:        authService,
 		UserService:        userService,
@@ -42,6 +45,7 @@ func NewHTTPRoutes(authService auth.Service, userService user.Service, teamServi
 		MonitoringService:  monitoringService,
 		TokenService:       tokenService,
 		GroupService:       groupService,
+		WebhookService:     webhookService,
 		Logger:             logger,
 	}
 }
@@ -49,6 +53,7 @@ func NewHTTPRoutes(authService auth.Service, userService user.Service, teamServi
 func (r *HTTPRoutes) RegisterUnauthenticatedRoutes(e *gin.RouterGroup) {
 	authRoute.AddAuthHandler(e, r.AuthService, r.Logger)
 	healthcheckRoute.AddHealthcheckHandler(e)
+	webhookRoute.AddWebhookHandler(e, r.WebhookService, webhookRoute.NewTranslator(), r.Logger)
 }
 
 func (r *HTTPRoutes) RegisterAuthenticatedRoutes(e *gin.RouterGroup) {
@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"cosmos-server/api"
+	"cosmos-server/pkg/model"
+)
+
+type Translator interface {
+	ToGithubWebhookResponse(applications []*model.Application) *api.GithubWebhookResponse
+}
+
+type translator struct{}
+
+func NewTranslator() Translator {
+	return &translator{}
+}
+
+func (t *translator) ToGithubWebhookResponse(applications []*model.Application) *api.GithubWebhookResponse {
+	queuedApplications := make([]string, 0, len(applications))
+	for _, application := range applications {
+		queuedApplications = append(queuedApplications, application.Name)
+	}
+
+	return &api.GithubWebhookResponse{
+		QueuedApplications: queuedApplications,
+	}
+}
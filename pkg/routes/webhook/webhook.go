@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/log"
+	"cosmos-server/pkg/services/webhook"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+
+	// GitHub caps webhook payloads at 25MB
+	maxWebhookPayloadBytes = 25 << 20
+)
+
+type handler struct {
+	webhookService webhook.Service
+	translator     Translator
+	logger         log.Logger
+}
+
+func AddWebhookHandler(e *gin.RouterGroup, webhookService webhook.Service, translator Translator, logger log.Logger) {
+	handler := &handler{
+		webhookService: webhookService,
+		translator:     translator,
+		logger:         logger,
+	}
+
+	webhooksGroup := e.Group("/webhooks")
+
+	webhooksGroup.POST("/github", handler.handleGithubWebhook)
+}
+
+func (handler *handler) handleGithubWebhook(e *gin.Context) {
+	payload, err := io.ReadAll(http.MaxBytesReader(e.Writer, e.Request.Body, maxWebhookPayloadBytes))
+	if err != nil {
+		handler.logger.Errorf("Failed to read GitHub webhook payload: %v", err)
+		_ = e.Error(errors.NewBadRequestError("failed to read webhook payload"))
+		return
+	}
+
+	queuedApplications, err := handler.webhookService.HandleGithubEvent(e, e.GetHeader(githubEventHeader), e.GetHeader(githubSignatureHeader), payload)
+	if err != nil {
+		handler.logger.Errorf("Failed to handle GitHub webhook: %v", err)
+		_ = e.Error(err)
+		return
+	}
+
+	e.JSON(http.StatusAccepted, handler.translator.ToGithubWebhookResponse(queuedApplications))
+}
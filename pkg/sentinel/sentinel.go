@@ -6,32 +6,72 @@ import (
 	"cosmos-server/pkg/model"
 	"cosmos-server/pkg/services/application"
 	"cosmos-server/pkg/services/monitoring"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
 	"sync"
 	"time"
 )
 
+const (
+	rateLimitResetMargin = 5 * time.Second
+	// Applications added or changed through the API are picked up by the next check, so checks are never further apart
+	maxScheduleDelay = time.Minute
+	minScheduleDelay = time.Second
+	// Workers look for new jobs this often when the queue is empty
+	syncJobPollInterval = time.Second
+	// Jobs running for longer than this were left behind by a replica that died, the leader puts them back in the queue.
+	// It is extended when the sync timeout is longer, the watchdog of a live replica always gets to its jobs first
+	staleSyncJobTimeout = 15 * time.Minute
+	// The watchdog looks for workers still busy with a job past its deadline this often
+	watchdogInterval = 10 * time.Second
+	// A cancelled sync returns right away, a worker still busy with it this long after the deadline is stuck
+	stuckWorkerGracePeriod = 30 * time.Second
+)
+
 type Sentinel struct {
 	applicationService application.Service
 	monitoringService  monitoring.Service
 	newConfigChannel   <-chan model.SentinelSettings
 	workerCount        int
-	jobsChan           chan *model.Application
 	logger             log.Logger
+
+	// Every replica runs workers, but only the one holding the leader lease schedules syncs
+	instanceID    string
+	leaseDuration time.Duration
+
+	syncTimeout         time.Duration
+	staleSyncJobTimeout time.Duration
+
+	// What every worker is doing, read by the watchdog and the admin endpoint
+	workersMutex sync.Mutex
+	workers      []*model.SentinelWorker
 }
 
-func NewSentinel(logger log.Logger, applicationService application.Service, monitoringService monitoring.Service, newSettingsChannel <-chan model.SentinelSettings, workerCount int) *Sentinel {
+func NewSentinel(logger log.Logger, applicationService application.Service, monitoringService monitoring.Service, newSettingsChannel <-chan model.SentinelSettings, workerCount int, leaseDuration, syncTimeout time.Duration) *Sentinel {
+	workers := make([]*model.SentinelWorker, 0, workerCount)
+	for i := 0; i < workerCount; i++ {
+		workers = append(workers, &model.SentinelWorker{ID: i})
+	}
+
 	return &Sentinel{
-		applicationService: applicationService,
-		monitoringService:  monitoringService,
-		newConfigChannel:   newSettingsChannel,
-		workerCount:        workerCount,
-		jobsChan:           make(chan *model.Application, 200),
-		logger:             logger,
+		applicationService:  applicationService,
+		monitoringService:   monitoringService,
+		newConfigChannel:    newSettingsChannel,
+		workerCount:         workerCount,
+		logger:              logger,
+		instanceID:          newInstanceID(),
+		leaseDuration:       leaseDuration,
+		syncTimeout:         syncTimeout,
+		staleSyncJobTimeout: max(staleSyncJobTimeout, syncTimeout+stuckWorkerGracePeriod+watchdogInterval),
+		workers:             workers,
 	}
 }
 
+// Start runs the sentinel until the context is cancelled, it returns once the workers are done with their jobs
 func (s *Sentinel) Start(ctx context.Context, fallbackConfig *model.SentinelSettings) {
-	s.logger.Infof("Sentinel starting with %d workers...", s.workerCount)
+	s.logger.Infof("Sentinel %s starting with %d workers...", s.instanceID, s.workerCount)
 
 	var wg sync.WaitGroup
 	for i := 0; i < s.workerCount; i++ {
@@ -45,30 +85,72 @@ func (s *Sentinel) Start(ctx context.Context, fallbackConfig *model.SentinelSett
 		initialSettings = fallbackConfig
 	}
 
-	ticker := time.NewTicker(time.Duration(initialSettings.Interval) * time.Second)
+	settings := *initialSettings
+	scheduleTimer := time.NewTimer(0)
+	scheduleTimer.Stop()
+
+	leaseTicker := time.NewTicker(s.leaseDuration / 3)
+	defer leaseTicker.Stop()
+
+	watchdogTicker := time.NewTicker(watchdogInterval)
+	defer watchdogTicker.Stop()
+
+	leader := s.renewLeadership(ctx)
+	if leader {
+		s.logger.Infof("Sentinel %s acquired leadership", s.instanceID)
+		s.resetSchedule(scheduleTimer, settings)
+	}
 
 	for {
 		select {
-		case <-ticker.C:
-			s.logger.Infof("Sentinel activated: checking applications for updates")
-			err := s.monitorApplications(ctx)
+		case <-scheduleTimer.C:
+			scheduleTimer.Reset(s.monitorApplications(ctx, settings.Interval))
+		case <-leaseTicker.C:
+			wasLeader := leader
+			leader = s.renewLeadership(ctx)
+			if !leader {
+				if wasLeader {
+					s.logger.Infof("Sentinel %s lost leadership, scheduled syncs stopped", s.instanceID)
+					scheduleTimer.Stop()
+				}
+				continue
+			}
+
+			if !wasLeader {
+				s.logger.Infof("Sentinel %s acquired leadership", s.instanceID)
+			}
+
+			if err := s.monitoringService.RequeueStaleSyncJobs(ctx, time.Now().Add(-s.staleSyncJobTimeout)); err != nil {
+				s.logger.Errorf("Failed to requeue stale sync jobs: %v", err)
+			}
+
+			// Settings updates received by other replicas only reach the leader through the database
+			latestSettings, err := s.monitoringService.GetSentinelSettings(ctx)
 			if err != nil {
-				s.logger.Errorf("Error checking applications: %v", err)
+				s.logger.Errorf("Failed to get sentinel settings: %v", err)
+				latestSettings = &settings
+			}
+
+			if !wasLeader || *latestSettings != settings {
+				settings = *latestSettings
+				s.resetSchedule(scheduleTimer, settings)
 			}
+		case <-watchdogTicker.C:
+			s.checkStuckWorkers(ctx)
 		case newSettings := <-s.newConfigChannel:
 			s.logger.Infof("Received new sentinel settings: %+v", newSettings)
-			ticker.Stop()
-			if newSettings.Enabled {
-				s.logger.Infof("Sentinel reconfigured: interval set to %d seconds", newSettings.Interval)
-				ticker = time.NewTicker(time.Duration(newSettings.Interval) * time.Second)
-			} else {
-				s.logger.Infof("Sentinel disabled")
-				ticker = &time.Ticker{}
-				ticker.C = nil
+			settings = newSettings
+			if leader {
+				s.resetSchedule(scheduleTimer, settings)
 			}
 		case <-ctx.Done():
 			s.logger.Infof("Sentinel stopping...")
-			close(s.jobsChan)
+			if leader {
+				// Releasing the lease lets another replica take over without waiting for it to expire
+				if err := s.monitoringService.ReleaseSentinelLeadership(context.WithoutCancel(ctx), s.instanceID); err != nil {
+					s.logger.Errorf("Failed to release sentinel leadership: %v", err)
+				}
+			}
 			wg.Wait()
 			s.logger.Infof("Sentinel stopped")
 			return
@@ -76,35 +158,107 @@ func (s *Sentinel) Start(ctx context.Context, fallbackConfig *model.SentinelSett
 	}
 }
 
-func (s *Sentinel) monitorApplications(ctx context.Context) error {
+func (s *Sentinel) renewLeadership(ctx context.Context) bool {
+	leader, err := s.monitoringService.AcquireSentinelLeadership(ctx, s.instanceID, s.leaseDuration)
+	if err != nil {
+		s.logger.Errorf("Failed to renew sentinel leadership: %v", err)
+		return false
+	}
+
+	return leader
+}
+
+func (s *Sentinel) resetSchedule(scheduleTimer *time.Timer, settings model.SentinelSettings) {
+	scheduleTimer.Stop()
+	if settings.Enabled {
+		s.logger.Infof("Sentinel reconfigured: interval set to %d seconds", settings.Interval)
+		scheduleTimer.Reset(0)
+	} else {
+		s.logger.Infof("Sentinel disabled")
+	}
+}
+
+// monitorApplications enqueues the applications whose sync is due and returns how long to wait before checking again
+func (s *Sentinel) monitorApplications(ctx context.Context, interval int) time.Duration {
 	applications, err := s.applicationService.GetApplicationsToMonitor(ctx)
 	if err != nil {
-		return err
+		s.logger.Errorf("Error checking applications: %v", err)
+		return maxScheduleDelay
+	}
+
+	activeJobs, err := s.monitoringService.GetActiveSyncJobs(ctx)
+	if err != nil {
+		s.logger.Errorf("Error checking applications: %v", err)
+		return maxScheduleDelay
+	}
+
+	// An application is only scheduled again once its queued or running job is done
+	activeApplications := make(map[string]bool, len(activeJobs))
+	for _, activeJob := range activeJobs {
+		if activeJob.Application != nil {
+			activeApplications[activeJob.Application.Name] = true
+		}
 	}
 
+	now := time.Now()
+	nextCheckAt := now.Add(maxScheduleDelay)
+	dueApplications := make([]*model.Application, 0)
+
 	for _, app := range applications {
-		select {
-		case s.jobsChan <- app:
-		case <-ctx.Done():
-			return ctx.Err()
+		if app.MonitoringInformation != nil && !app.MonitoringInformation.SyncEnabled {
+			continue
+		}
+		if activeApplications[app.Name] {
+			continue
+		}
+
+		dueAt := getNextSyncAt(app, interval)
+		if !dueAt.After(now) {
+			dueApplications = append(dueApplications, app)
+		} else if dueAt.Before(nextCheckAt) {
+			nextCheckAt = dueAt
+		}
+	}
+
+	if len(dueApplications) > 0 {
+		s.logger.Infof("Sentinel activated: %d applications due for a check", len(dueApplications))
+		if _, err := s.monitoringService.EnqueueApplicationsSync(ctx, model.SentinelRunTriggerScheduled, dueApplications); err != nil {
+			s.logger.Errorf("Error checking applications: %v", err)
 		}
 	}
 
-	return nil
+	return max(time.Until(nextCheckAt), minScheduleDelay)
+}
+
+func getNextSyncAt(app *model.Application, interval int) time.Time {
+	if app.SyncStatus == nil || app.SyncStatus.LastAttemptAt == nil {
+		return time.Time{}
+	}
+
+	if app.MonitoringInformation != nil && app.MonitoringInformation.SyncInterval != nil {
+		interval = *app.MonitoringInformation.SyncInterval
+	}
+
+	return app.SyncStatus.LastAttemptAt.Add(time.Duration(interval) * time.Second)
 }
 
 func (s *Sentinel) worker(ctx context.Context, id int, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for {
+		job, err := s.monitoringService.ClaimSyncJob(ctx, s.instanceID)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Errorf("Worker %d: Failed to claim sync job: %v", id, err)
+		}
+
+		if job != nil {
+			s.logger.Infow("Monitoring application", "worker", id, "application", job.Application.Name, "job", job.ID)
+			s.monitorApplication(ctx, job, id)
+			continue
+		}
+
 		select {
-		case app, ok := <-s.jobsChan:
-			if !ok {
-				s.logger.Infow("Jobs channel closed, exiting", "worker", id)
-				return
-			}
-			s.logger.Infow("Monitoring application", "worker", id, "application", app.Name)
-			s.monitorApplication(ctx, app, id)
+		case <-time.After(syncJobPollInterval):
 		case <-ctx.Done():
 			s.logger.Infow("Context cancelled", "worker", id)
 			return
@@ -112,12 +266,128 @@ func (s *Sentinel) worker(ctx context.Context, id int, wg *sync.WaitGroup) {
 	}
 }
 
-func (s *Sentinel) monitorApplication(ctx context.Context, app *model.Application, workerID int) {
-	if err := s.monitoringService.UpdateApplicationDependencies(ctx, app); err != nil {
-		s.logger.Errorf("Worker %d: Failed to update dependencies for application %s: %v", workerID, app.Name, err)
+func (s *Sentinel) monitorApplication(ctx context.Context, job *model.SyncJob, workerID int) {
+	app := job.Application
+	startedAt := time.Now()
+	deadline := startedAt.Add(s.syncTimeout)
+	s.startWorkerJob(workerID, job, startedAt, deadline)
+
+	syncCtx, cancel := context.WithDeadline(ctx, deadline)
+	result := s.monitoringService.SyncApplication(syncCtx, app)
+	timedOut := errors.Is(syncCtx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	if stuck := s.finishWorkerJob(workerID); stuck {
+		s.logger.Warnf("Worker %d: Sync of application %s returned after %s, job %d had already gone back to the queue", workerID, app.Name, time.Since(startedAt).Round(time.Second), job.ID)
+		return
+	}
+
+	// The outcome is stored even if the sentinel is shutting down, so the job is not left running
+	recordCtx := context.WithoutCancel(ctx)
+
+	if ctx.Err() != nil {
+		s.logger.Infof("Worker %d: Sync of application %s interrupted, job %d goes back to the queue", workerID, app.Name, job.ID)
+		if err := s.monitoringService.RequeueSyncJob(recordCtx, job, time.Now(), "sync was interrupted by a shutdown"); err != nil {
+			s.logger.Errorf("Worker %d: Failed to requeue sync job %d: %v", workerID, job.ID, err)
+		}
+		return
+	}
+
+	if timedOut {
+		s.logger.Errorf("Worker %d: Sync of application %s exceeded its deadline of %s", workerID, app.Name, s.syncTimeout)
+	}
+
+	if result.DependenciesErr != nil {
+		s.logger.Errorf("Worker %d: Failed to update dependencies for application %s: %v", workerID, app.Name, result.DependenciesErr)
+	}
+
+	if result.OpenAPIErr != nil {
+		s.logger.Errorf("Worker %d: Failed to update OpenAPI specification for application %s: %v", workerID, app.Name, result.OpenAPIErr)
+	}
+
+	var rateLimitErr *model.RateLimitError
+	if errors.As(result.DependenciesErr, &rateLimitErr) || errors.As(result.OpenAPIErr, &rateLimitErr) {
+		s.logger.Infof("Git provider rate limit reached, pausing sync of application %s until %s", app.Name, rateLimitErr.ResetAt.Format(time.RFC3339))
+		if err := s.monitoringService.RequeueSyncJob(recordCtx, job, rateLimitErr.ResetAt.Add(rateLimitResetMargin), result.ErrorMessage()); err != nil {
+			s.logger.Errorf("Worker %d: Failed to requeue sync job %d: %v", workerID, job.ID, err)
+		}
+		return
+	}
+
+	if err := s.monitoringService.CompleteSyncJob(recordCtx, job, result); err != nil {
+		s.logger.Errorf("Worker %d: Failed to complete sync job %d for application %s: %v", workerID, job.ID, app.Name, err)
+	}
+}
+
+func (s *Sentinel) startWorkerJob(workerID int, job *model.SyncJob, startedAt, deadline time.Time) {
+	s.workersMutex.Lock()
+	defer s.workersMutex.Unlock()
+
+	s.workers[workerID] = &model.SentinelWorker{
+		ID:        workerID,
+		Job:       job,
+		StartedAt: &startedAt,
+		Deadline:  &deadline,
+	}
+}
+
+// finishWorkerJob marks the worker as idle and tells whether the watchdog found it stuck meanwhile
+func (s *Sentinel) finishWorkerJob(workerID int) bool {
+	s.workersMutex.Lock()
+	defer s.workersMutex.Unlock()
+
+	stuck := s.workers[workerID].Stuck
+	s.workers[workerID] = &model.SentinelWorker{ID: workerID}
+
+	return stuck
+}
+
+// checkStuckWorkers hands the jobs of the workers still busy past their deadline back to the queue, so their
+// applications are synced by another worker instead of waiting for the stuck one
+func (s *Sentinel) checkStuckWorkers(ctx context.Context) {
+	now := time.Now()
+	stuckWorkers := make([]model.SentinelWorker, 0)
+
+	s.workersMutex.Lock()
+	for _, worker := range s.workers {
+		if worker.Job == nil || worker.Stuck || now.Before(worker.Deadline.Add(stuckWorkerGracePeriod)) {
+			continue
+		}
+		worker.Stuck = true
+		stuckWorkers = append(stuckWorkers, *worker)
+	}
+	s.workersMutex.Unlock()
+
+	for _, worker := range stuckWorkers {
+		s.logger.Errorf("Worker %d: Stuck on application %s for %s, past its deadline of %s, job %d goes back to the queue", worker.ID, worker.Job.Application.Name, now.Sub(*worker.StartedAt).Round(time.Second), s.syncTimeout, worker.Job.ID)
+		if err := s.monitoringService.RequeueSyncJob(ctx, worker.Job, now, "sync exceeded its deadline and its worker got stuck"); err != nil {
+			s.logger.Errorf("Worker %d: Failed to requeue sync job %d: %v", worker.ID, worker.Job.ID, err)
+		}
+	}
+}
+
+// Workers returns what every worker of this replica is doing
+func (s *Sentinel) Workers() []*model.SentinelWorker {
+	s.workersMutex.Lock()
+	defer s.workersMutex.Unlock()
+
+	workers := make([]*model.SentinelWorker, 0, len(s.workers))
+	for _, worker := range s.workers {
+		workerCopy := *worker
+		workers = append(workers, &workerCopy)
 	}
 
-	if err := s.monitoringService.UpdateApplicationOpenAPISpecification(ctx, app); err != nil {
-		s.logger.Errorf("Worker %d: Failed to update OpenAPI specification for application %s: %v", workerID, app.Name, err)
+	return workers
+}
+
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "cosmos"
 	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return hostname + "-" + hex.EncodeToString(suffix)
 }
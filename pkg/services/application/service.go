@@ -8,6 +8,7 @@ import (
 	"cosmos-server/pkg/storage"
 	"cosmos-server/pkg/storage/obj"
 	errorUtils "errors"
+	"fmt"
 )
 
 //go:generate mockgen -destination=./mock/service_mock.go -package=mock cosmos-server/pkg/services/application Service
@@ -21,23 +22,30 @@ type Service interface {
 	UpdateApplication(ctx context.Context, name string, updateData *model.ApplicationUpdate) (*model.Application, error)
 
 	GetApplicationsToMonitor(ctx context.Context) ([]*model.Application, error)
+	GetApplicationsByRepository(ctx context.Context, provider, owner, repositoryName, branch string) ([]*model.Application, error)
 }
 
 type applicationService struct {
-	storageService storage.Service
-	translator     Translator
-	logger         log.Logger
+	storageService             storage.Service
+	sentinelMinIntervalSeconds int
+	translator                 Translator
+	logger                     log.Logger
 }
 
-func NewApplicationService(storageService storage.Service, translator Translator, logger log.Logger) Service {
+func NewApplicationService(storageService storage.Service, sentinelMinIntervalSeconds int, translator Translator, logger log.Logger) Service {
 	return &applicationService{
-		storageService: storageService,
-		translator:     translator,
-		logger:         logger,
+		storageService:             storageService,
+		sentinelMinIntervalSeconds: sentinelMinIntervalSeconds,
+		translator:                 translator,
+		logger:                     logger,
 	}
 }
 
 func (s *applicationService) AddApplication(ctx context.Context, name, description, team string, gitInformation *model.GitInformation, monitoringInformation *model.MonitoringInformation, tokenName string) error {
+	if err := s.validateMonitoringInformation(monitoringInformation); err != nil {
+		return err
+	}
+
 	applicationObj := &obj.Application{
 		Name:        name,
 		Description: description,
@@ -67,6 +75,8 @@ func (s *applicationService) AddApplication(ctx context.Context, name, descripti
 		applicationObj.HasOpenApi = monitoringInformation.HasOpenApi
 		applicationObj.OpenApiPath = monitoringInformation.OpenApiPath
 		applicationObj.OpenClientPath = monitoringInformation.OpenClientPath
+		applicationObj.SyncInterval = monitoringInformation.SyncInterval
+		applicationObj.SyncDisabled = !monitoringInformation.SyncEnabled
 	}
 
 	if tokenName != "" {
@@ -149,6 +159,10 @@ func (s *applicationService) DeleteApplication(ctx context.Context, name string)
 }
 
 func (s *applicationService) UpdateApplication(ctx context.Context, name string, updateData *model.ApplicationUpdate) (*model.Application, error) {
+	if err := s.validateMonitoringInformation(updateData.MonitoringInformation); err != nil {
+		return nil, err
+	}
+
 	existingApp, err := s.storageService.GetApplicationWithName(ctx, name)
 	if err != nil {
 		if errorUtils.Is(err, storage.ErrNotFound) {
@@ -162,20 +176,30 @@ func (s *applicationService) UpdateApplication(ctx context.Context, name string,
 			ID:        existingApp.ID,
 			CreatedAt: existingApp.CreatedAt,
 		},
-		Name:                existingApp.Name,
-		Description:         existingApp.Description,
-		TeamID:              existingApp.TeamID,
-		GitProvider:         existingApp.GitProvider,
-		GitRepositoryOwner:  existingApp.GitRepositoryOwner,
-		GitRepositoryName:   existingApp.GitRepositoryName,
-		GitRepositoryBranch: existingApp.GitRepositoryBranch,
-		DependenciesSha:     existingApp.DependenciesSha,
-		OpenAPISha:          existingApp.OpenAPISha,
-		HasOpenApi:          existingApp.HasOpenApi,
-		OpenApiPath:         existingApp.OpenApiPath,
-		HasOpenClient:       existingApp.HasOpenClient,
-		OpenClientPath:      existingApp.OpenClientPath,
-		TokenID:             existingApp.TokenID,
+		Name:                         existingApp.Name,
+		Description:                  existingApp.Description,
+		TeamID:                       existingApp.TeamID,
+		GitProvider:                  existingApp.GitProvider,
+		GitRepositoryOwner:           existingApp.GitRepositoryOwner,
+		GitRepositoryName:            existingApp.GitRepositoryName,
+		GitRepositoryBranch:          existingApp.GitRepositoryBranch,
+		DependenciesSha:              existingApp.DependenciesSha,
+		OpenAPISha:                   existingApp.OpenAPISha,
+		CommitSha:                    existingApp.CommitSha,
+		HasOpenApi:                   existingApp.HasOpenApi,
+		OpenApiPath:                  existingApp.OpenApiPath,
+		HasOpenClient:                existingApp.HasOpenClient,
+		OpenClientPath:               existingApp.OpenClientPath,
+		SyncInterval:                 existingApp.SyncInterval,
+		SyncDisabled:                 existingApp.SyncDisabled,
+		TokenID:                      existingApp.TokenID,
+		LastSyncAttemptAt:            existingApp.LastSyncAttemptAt,
+		LastSyncSuccessAt:            existingApp.LastSyncSuccessAt,
+		LastSyncError:                existingApp.LastSyncError,
+		LastSyncErrorCategory:        existingApp.LastSyncErrorCategory,
+		ConsecutiveSyncFailures:      existingApp.ConsecutiveSyncFailures,
+		ConsecutivePermanentFailures: existingApp.ConsecutivePermanentFailures,
+		QuarantinedAt:                existingApp.QuarantinedAt,
 	}
 
 	if updateData.Name != nil {
@@ -206,12 +230,23 @@ func (s *applicationService) UpdateApplication(ctx context.Context, name string,
 		updateObj.GitRepositoryOwner = updateData.GitInformation.RepositoryOwner
 		updateObj.GitRepositoryName = updateData.GitInformation.RepositoryName
 		updateObj.GitRepositoryBranch = updateData.GitInformation.RepositoryBranch
-		if updateData.MonitoringInformation != nil {
-			updateObj.HasOpenApi = updateData.MonitoringInformation.HasOpenApi
-			updateObj.OpenApiPath = updateData.MonitoringInformation.OpenApiPath
-			updateObj.HasOpenClient = updateData.MonitoringInformation.HasOpenClient
-			updateObj.OpenClientPath = updateData.MonitoringInformation.OpenClientPath
-		}
+	}
+
+	if updateData.MonitoringInformation != nil {
+		updateObj.HasOpenApi = updateData.MonitoringInformation.HasOpenApi
+		updateObj.OpenApiPath = updateData.MonitoringInformation.OpenApiPath
+		updateObj.HasOpenClient = updateData.MonitoringInformation.HasOpenClient
+		updateObj.OpenClientPath = updateData.MonitoringInformation.OpenClientPath
+		updateObj.SyncInterval = updateData.MonitoringInformation.SyncInterval
+	}
+
+	if updateData.SyncEnabled != nil {
+		updateObj.SyncDisabled = !*updateData.SyncEnabled
+	}
+
+	// The repository or monitored files changed, so the next sync must not be skipped
+	if hasMonitoredFilesChanged(existingApp, updateObj) {
+		updateObj.CommitSha = ""
 	}
 
 	if updateData.TokenName != nil {
@@ -233,6 +268,12 @@ func (s *applicationService) UpdateApplication(ctx context.Context, name string,
 		}
 	}
 
+	// Changing what is synced releases the application from quarantine, so the new configuration is synced
+	if hasSyncConfigurationChanged(existingApp, updateObj) {
+		updateObj.ConsecutivePermanentFailures = 0
+		updateObj.QuarantinedAt = nil
+	}
+
 	err = s.storageService.UpdateApplication(ctx, updateObj)
 	if err != nil {
 		if errorUtils.Is(err, storage.ErrAlreadyExists) {
@@ -260,3 +301,49 @@ func (s *applicationService) GetApplicationsToMonitor(ctx context.Context) ([]*m
 
 	return s.translator.ToApplicationModels(applications), nil
 }
+
+func (s *applicationService) GetApplicationsByRepository(ctx context.Context, provider, owner, repositoryName, branch string) ([]*model.Application, error) {
+	applications, err := s.storageService.GetApplicationsByRepository(ctx, provider, owner, repositoryName, branch)
+	if err != nil {
+		return nil, errors.NewInternalServerError("failed to retrieve applications for repository: " + err.Error())
+	}
+
+	return s.translator.ToApplicationModels(applications), nil
+}
+
+// hasSyncConfigurationChanged tells whether the repository, the monitored files or the token of an application changed,
+// the changes that may fix the permanent failures of its syncs
+func hasSyncConfigurationChanged(existingApp, updatedApp *obj.Application) bool {
+	return hasMonitoredFilesChanged(existingApp, updatedApp) || !equalTokenIDs(existingApp.TokenID, updatedApp.TokenID)
+}
+
+// hasMonitoredFilesChanged tells whether the repository, the branch or the monitored files of an application changed
+func hasMonitoredFilesChanged(existingApp, updatedApp *obj.Application) bool {
+	return existingApp.GitProvider != updatedApp.GitProvider ||
+		existingApp.GitRepositoryOwner != updatedApp.GitRepositoryOwner ||
+		existingApp.GitRepositoryName != updatedApp.GitRepositoryName ||
+		existingApp.GitRepositoryBranch != updatedApp.GitRepositoryBranch ||
+		existingApp.HasOpenApi != updatedApp.HasOpenApi ||
+		existingApp.OpenApiPath != updatedApp.OpenApiPath ||
+		existingApp.HasOpenClient != updatedApp.HasOpenClient ||
+		existingApp.OpenClientPath != updatedApp.OpenClientPath
+}
+
+func equalTokenIDs(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (s *applicationService) validateMonitoringInformation(monitoringInformation *model.MonitoringInformation) error {
+	if monitoringInformation == nil || monitoringInformation.SyncInterval == nil {
+		return nil
+	}
+
+	if *monitoringInformation.SyncInterval < s.sentinelMinIntervalSeconds {
+		return errors.NewBadRequestError(fmt.Sprintf("sync interval must be at least %d seconds", s.sentinelMinIntervalSeconds))
+	}
+
+	return nil
+}
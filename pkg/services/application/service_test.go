@@ -9,6 +9,7 @@ import (
 	"cosmos-server/pkg/storage/obj"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -21,6 +22,7 @@ func TestAddApplication(t *testing.T) {
 	t.Run("add application - no team success", addApplicationNoTeamSuccess)
 	t.Run("add application - invalid team error", addApplicationInvalidTeamError)
 	t.Run("add application - insert application error", addApplicationInsertApplicationError)
+	t.Run("add application - sync interval below minimum error", addApplicationSyncIntervalBelowMinimumError)
 }
 
 func TestGetApplication(t *testing.T) {
@@ -58,8 +60,15 @@ func TestUpdateApplication(t *testing.T) {
 	t.Run("update application - name conflict error", updateApplicationNameConflictError)
 	t.Run("update application - storage error", updateApplicationStorageError)
 	t.Run("update application - get updated application error", updateApplicationGetUpdatedError)
+	t.Run("update application - sync interval below minimum error", updateApplicationSyncIntervalBelowMinimumError)
+	t.Run("update application description - quarantine is kept", updateApplicationDescriptionKeepsQuarantine)
+	t.Run("update application git information - quarantine is released", updateApplicationGitInformationReleasesQuarantine)
+	t.Run("update application schedule - commit sha is kept", updateApplicationScheduleKeepsCommitSha)
+	t.Run("update application monitoring information without sync enabled - sync stays disabled", updateApplicationMonitoringKeepsSyncDisabled)
 }
 
+const mockedSentinelMinInterval = 60
+
 type mocks struct {
 	controller         *gomock.Controller
 	storageServiceMock *storageMock.MockService
@@ -75,7 +84,7 @@ func setUp(t *testing.T) (Service, *mocks) {
 		loggerMocks:        log.NewMockLogger(ctrl),
 	}
 
-	applicationService := NewApplicationService(mocks.storageServiceMock, NewTranslator(), mocks.loggerMocks)
+	applicationService := NewApplicationService(mocks.storageServiceMock, mockedSentinelMinInterval, NewTranslator(), mocks.loggerMocks)
 	return applicationService, mocks
 }
 
@@ -164,6 +173,22 @@ func addApplicationInvalidTeamError(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "team not found"))
 }
 
+func addApplicationSyncIntervalBelowMinimumError(t *testing.T) {
+	applicationService, _ := setUp(t)
+
+	syncInterval := mockedSentinelMinInterval - 1
+	monitoringInformation := &model.MonitoringInformation{
+		HasOpenApi:   true,
+		OpenApiPath:  "openapi.yaml",
+		SyncInterval: &syncInterval,
+		SyncEnabled:  true,
+	}
+
+	err := applicationService.AddApplication(context.Background(), "test-application", "test-description", "", nil, monitoringInformation, "")
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "sync interval must be at least 60 seconds"))
+}
+
 func addApplicationInsertApplicationError(t *testing.T) {
 	applicationService, mocks := setUp(t)
 
@@ -795,6 +820,25 @@ func updateApplicationNotFoundError(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "application not found"))
 }
 
+func updateApplicationSyncIntervalBelowMinimumError(t *testing.T) {
+	applicationService, _ := setUp(t)
+
+	syncInterval := mockedSentinelMinInterval - 1
+	updateData := &model.ApplicationUpdate{
+		MonitoringInformation: &model.MonitoringInformation{
+			HasOpenApi:   true,
+			OpenApiPath:  "openapi.yaml",
+			SyncInterval: &syncInterval,
+			SyncEnabled:  true,
+		},
+	}
+
+	result, err := applicationService.UpdateApplication(context.Background(), "test-application", updateData)
+	require.Error(t, err)
+	require.Nil(t, result)
+	require.True(t, strings.Contains(err.Error(), "sync interval must be at least 60 seconds"))
+}
+
 func updateApplicationInvalidTeamError(t *testing.T) {
 	applicationService, mocks := setUp(t)
 
@@ -950,3 +994,190 @@ func updateApplicationGetUpdatedError(t *testing.T) {
 	require.Nil(t, result)
 	require.True(t, strings.Contains(err.Error(), "failed to retrieve updated application"))
 }
+
+func updateApplicationDescriptionKeepsQuarantine(t *testing.T) {
+	applicationService, mocks := setUp(t)
+
+	applicationName := "test-application"
+	newDescription := "updated description"
+	quarantinedAt := time.Now().Add(-time.Hour)
+
+	existingApp := &obj.Application{
+		CosmosObj:                    obj.CosmosObj{ID: 1},
+		Name:                         applicationName,
+		Description:                  "original description",
+		GitProvider:                  "github",
+		GitRepositoryOwner:           "test-owner",
+		GitRepositoryName:            "test-repo",
+		GitRepositoryBranch:          "main",
+		ConsecutivePermanentFailures: 5,
+		QuarantinedAt:                &quarantinedAt,
+	}
+
+	expectedUpdateObj := *existingApp
+	expectedUpdateObj.Description = newDescription
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(existingApp, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		UpdateApplication(gomock.Any(), &expectedUpdateObj).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(&expectedUpdateObj, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	_, err := applicationService.UpdateApplication(context.Background(), applicationName, &model.ApplicationUpdate{Description: &newDescription})
+	require.NoError(t, err)
+}
+
+func updateApplicationGitInformationReleasesQuarantine(t *testing.T) {
+	applicationService, mocks := setUp(t)
+
+	applicationName := "test-application"
+	quarantinedAt := time.Now().Add(-time.Hour)
+
+	existingApp := &obj.Application{
+		CosmosObj:                    obj.CosmosObj{ID: 1},
+		Name:                         applicationName,
+		GitProvider:                  "github",
+		GitRepositoryOwner:           "test-owner",
+		GitRepositoryName:            "test-repo",
+		GitRepositoryBranch:          "main",
+		CommitSha:                    "0123456789abcdef",
+		ConsecutivePermanentFailures: 5,
+		QuarantinedAt:                &quarantinedAt,
+	}
+
+	expectedUpdateObj := *existingApp
+	expectedUpdateObj.GitRepositoryName = "renamed-repo"
+	expectedUpdateObj.CommitSha = ""
+	expectedUpdateObj.ConsecutivePermanentFailures = 0
+	expectedUpdateObj.QuarantinedAt = nil
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(existingApp, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		UpdateApplication(gomock.Any(), &expectedUpdateObj).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(&expectedUpdateObj, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	_, err := applicationService.UpdateApplication(context.Background(), applicationName, &model.ApplicationUpdate{
+		GitInformation: &model.GitInformation{
+			Provider:         "github",
+			RepositoryOwner:  "test-owner",
+			RepositoryName:   "renamed-repo",
+			RepositoryBranch: "main",
+		},
+	})
+	require.NoError(t, err)
+}
+
+func updateApplicationScheduleKeepsCommitSha(t *testing.T) {
+	applicationService, mocks := setUp(t)
+
+	applicationName := "test-application"
+	syncInterval := 600
+	syncEnabled := false
+
+	existingApp := &obj.Application{
+		CosmosObj:           obj.CosmosObj{ID: 1},
+		Name:                applicationName,
+		GitProvider:         "github",
+		GitRepositoryOwner:  "test-owner",
+		GitRepositoryName:   "test-repo",
+		GitRepositoryBranch: "main",
+		CommitSha:           "0123456789abcdef",
+		HasOpenApi:          true,
+		OpenApiPath:         "docs/openapi.json",
+	}
+
+	expectedUpdateObj := *existingApp
+	expectedUpdateObj.SyncInterval = &syncInterval
+	expectedUpdateObj.SyncDisabled = true
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(existingApp, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		UpdateApplication(gomock.Any(), &expectedUpdateObj).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(&expectedUpdateObj, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	_, err := applicationService.UpdateApplication(context.Background(), applicationName, &model.ApplicationUpdate{
+		MonitoringInformation: &model.MonitoringInformation{
+			HasOpenApi:   true,
+			OpenApiPath:  "docs/openapi.json",
+			SyncInterval: &syncInterval,
+		},
+		SyncEnabled: &syncEnabled,
+	})
+	require.NoError(t, err)
+}
+
+func updateApplicationMonitoringKeepsSyncDisabled(t *testing.T) {
+	applicationService, mocks := setUp(t)
+
+	applicationName := "test-application"
+
+	existingApp := &obj.Application{
+		CosmosObj:           obj.CosmosObj{ID: 1},
+		Name:                applicationName,
+		GitProvider:         "github",
+		GitRepositoryOwner:  "test-owner",
+		GitRepositoryName:   "test-repo",
+		GitRepositoryBranch: "main",
+		CommitSha:           "0123456789abcdef",
+		HasOpenApi:          true,
+		OpenApiPath:         "docs/openapi.json",
+		SyncDisabled:        true,
+	}
+
+	expectedUpdateObj := *existingApp
+	expectedUpdateObj.OpenApiPath = "api/openapi.yaml"
+	expectedUpdateObj.CommitSha = ""
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(existingApp, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		UpdateApplication(gomock.Any(), &expectedUpdateObj).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), applicationName).
+		Return(&expectedUpdateObj, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	_, err := applicationService.UpdateApplication(context.Background(), applicationName, &model.ApplicationUpdate{
+		MonitoringInformation: &model.MonitoringInformation{
+			HasOpenApi:  true,
+			OpenApiPath: "api/openapi.yaml",
+			SyncEnabled: true,
+		},
+	})
+	require.NoError(t, err)
+}
@@ -23,6 +23,7 @@ func (t *translator) ToApplicationModel(applicationObj *obj.Application) *model.
 		Team:                  t.ToModelTeam(applicationObj.Team),
 		MonitoringInformation: t.ToModelMonitoringInformation(applicationObj),
 		Token:                 t.ToModelToken(applicationObj.Token),
+		SyncStatus:            t.ToModelSyncStatus(applicationObj),
 	}
 
 	if applicationObj.GitProvider != "" || applicationObj.GitRepositoryName != "" || applicationObj.GitRepositoryOwner != "" || applicationObj.GitRepositoryBranch != "" {
@@ -55,6 +56,22 @@ func (t *translator) ToApplicationModels(applicationObjs []*obj.Application) []*
 	return applicationModels
 }
 
+func (t *translator) ToModelSyncStatus(applicationObj *obj.Application) *model.SyncStatus {
+	if applicationObj == nil {
+		return nil
+	}
+
+	return &model.SyncStatus{
+		LastAttemptAt:                applicationObj.LastSyncAttemptAt,
+		LastSuccessAt:                applicationObj.LastSyncSuccessAt,
+		LastError:                    applicationObj.LastSyncError,
+		LastErrorCategory:            applicationObj.LastSyncErrorCategory,
+		ConsecutiveFailures:          applicationObj.ConsecutiveSyncFailures,
+		ConsecutivePermanentFailures: applicationObj.ConsecutivePermanentFailures,
+		QuarantinedAt:                applicationObj.QuarantinedAt,
+	}
+}
+
 func (t *translator) ToModelMonitoringInformation(applicationObj *obj.Application) *model.MonitoringInformation {
 	if applicationObj == nil {
 		return nil
@@ -63,10 +80,13 @@ func (t *translator) ToModelMonitoringInformation(applicationObj *obj.Applicatio
 	return &model.MonitoringInformation{
 		DependenciesSha: applicationObj.DependenciesSha,
 		OpenAPISha:      applicationObj.OpenAPISha,
+		CommitSha:       applicationObj.CommitSha,
 		HasOpenApi:      applicationObj.HasOpenApi,
 		OpenApiPath:     applicationObj.OpenApiPath,
 		HasOpenClient:   applicationObj.HasOpenClient,
 		OpenClientPath:  applicationObj.OpenClientPath,
+		SyncInterval:    applicationObj.SyncInterval,
+		SyncEnabled:     !applicationObj.SyncDisabled,
 	}
 }
 
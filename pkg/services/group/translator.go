@@ -84,10 +84,13 @@ func (t *translator) ToModelMonitoringInformation(applicationObj *obj.Applicatio
 	return &model.MonitoringInformation{
 		DependenciesSha: applicationObj.DependenciesSha,
 		OpenAPISha:      applicationObj.OpenAPISha,
+		CommitSha:       applicationObj.CommitSha,
 		HasOpenApi:      applicationObj.HasOpenApi,
 		OpenApiPath:     applicationObj.OpenApiPath,
 		HasOpenClient:   applicationObj.HasOpenClient,
 		OpenClientPath:  applicationObj.OpenClientPath,
+		SyncInterval:    applicationObj.SyncInterval,
+		SyncEnabled:     !applicationObj.SyncDisabled,
 	}
 }
 
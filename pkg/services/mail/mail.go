@@ -20,9 +20,34 @@ import (
 
 type Service interface {
 	SendMail(to string, subject string, body string) error
-	SendOpenAPIDifferencesNotification(ctx context.Context, updatedApplication *model.Application, applicationDependencies []*model.AppEndpointDependencies, changes checker.Changes)
+	// SendOpenAPIDifferencesNotification notifies the consumers of the changes to the operations they declare, matcher matches their declarations against the previous specification
+	SendOpenAPIDifferencesNotification(ctx context.Context, updatedApplication *model.Application, matcher *model.OperationMatcher, applicationDependencies []*model.AppEndpointDependencies, changes checker.Changes)
 }
 
+var (
+	// requiredChangeMarkers are the changes making a parameter or a property required, they break every consumer of the
+	// operation whether or not it declares them
+	requiredChangeMarkers = []string{"became-required", "new-required-"}
+	// parameterChangePrefixes are the changes to a request parameter, their arguments hold its location and its name
+	parameterChangePrefixes = []string{"request-parameter-", "request-header-property-"}
+	// parameterNameArgIndexes gives the argument holding the name of the parameter of the changes not following the
+	// location and name layout, the other arguments being values like an enum value or a pattern
+	parameterNameArgIndexes = map[string]int{
+		"request-parameter-pattern-added":                   2,
+		"request-parameter-pattern-removed":                 2,
+		"request-parameter-enum-value-added":                2,
+		"request-parameter-enum-value-removed":              2,
+		"request-parameter-x-extensible-enum-value-removed": 2,
+		"request-parameter-property-list-of-types-widened":  2,
+		"request-parameter-property-list-of-types-narrowed": 2,
+	}
+	// fieldChangePrefixes are the changes to a property of a request or response body, their first argument is its name
+	fieldChangePrefixes = []string{
+		"request-property-", "request-optional-property-", "request-required-property-", "request-read-only-property-",
+		"response-property-", "response-optional-", "response-required-", "response-write-only-property-",
+	}
+)
+
 type mailService struct {
 	client         *mail.Client
 	senderMail     string
@@ -72,7 +97,7 @@ func (ms *mailService) SendMail(to string, subject string, body string) error {
 	return nil
 }
 
-func (ms *mailService) SendOpenAPIDifferencesNotification(ctx context.Context, updatedApplication *model.Application, applicationDependencies []*model.AppEndpointDependencies, changes checker.Changes) {
+func (ms *mailService) SendOpenAPIDifferencesNotification(ctx context.Context, updatedApplication *model.Application, matcher *model.OperationMatcher, applicationDependencies []*model.AppEndpointDependencies, changes checker.Changes) {
 	teamMembersEmails := make(map[string][]string)
 
 	for _, appDep := range applicationDependencies {
@@ -80,7 +105,7 @@ func (ms *mailService) SendOpenAPIDifferencesNotification(ctx context.Context, u
 			continue
 		}
 
-		relevantChanges := ms.filterRelevantChanges(changes, appDep.Endpoints)
+		relevantChanges := ms.filterRelevantChanges(changes, matcher, appDep)
 		if len(relevantChanges) == 0 {
 			ms.logger.Infof("No relevant changes for application %s depending on %s, skipping email notification", appDep.Application.Name, updatedApplication.Name)
 			continue
@@ -107,11 +132,11 @@ func (ms *mailService) SendOpenAPIDifferencesNotification(ctx context.Context, u
 	}
 }
 
-func (ms *mailService) filterRelevantChanges(changes checker.Changes, appEndpoints map[string]bool) checker.Changes {
+func (ms *mailService) filterRelevantChanges(changes checker.Changes, matcher *model.OperationMatcher, appDependency *model.AppEndpointDependencies) checker.Changes {
 	relevantChanges := make(checker.Changes, 0)
 
 	for _, change := range changes {
-		if ms.isChangeRelevant(change, appEndpoints) {
+		if ms.isChangeRelevant(change, matcher, appDependency) {
 			relevantChanges = append(relevantChanges, change)
 		}
 	}
@@ -119,17 +144,75 @@ func (ms *mailService) filterRelevantChanges(changes checker.Changes, appEndpoin
 	return relevantChanges
 }
 
-func (ms *mailService) isChangeRelevant(change checker.Change, appEndpoints map[string]bool) bool {
-	endpoint := change.GetPath()
-	method := change.GetOperation()
-
-	if endpoint == "" || method == "" {
+// isChangeRelevant keeps the changes to the operations the application declares, narrowed down to the changes touching
+// the parameters and fields it declares when it does. The changes not scoped to a parameter or a field, and the changes
+// making a parameter or a property required, concern every consumer of the operation
+func (ms *mailService) isChangeRelevant(change checker.Change, matcher *model.OperationMatcher, appDependency *model.AppEndpointDependencies) bool {
+	details, ok := appDependency.GetDeclaredOperation(matcher, change.GetOperation(), change.GetPath())
+	if !ok {
 		return false
 	}
 
-	key := strings.ToLower(method) + " " + strings.ToLower(endpoint)
-	_, exists := appEndpoints[key]
-	return exists
+	if !details.DeclaresFields() {
+		return true
+	}
+
+	id := change.GetId()
+	args := change.GetArgs()
+
+	if containsAny(id, requiredChangeMarkers) {
+		return true
+	}
+
+	if hasAnyPrefix(id, parameterChangePrefixes) {
+		index := getParameterNameArgIndex(id)
+		if index >= len(args) {
+			return true
+		}
+		name, isString := args[index].(string)
+		return !isString || details.UsesParameter(name)
+	}
+
+	if hasAnyPrefix(id, fieldChangePrefixes) && len(args) > 0 {
+		property, isString := args[0].(string)
+		return !isString || details.UsesField(property)
+	}
+
+	return true
+}
+
+// getParameterNameArgIndex gives the argument holding the name of the parameter, the header of a request header
+// property change being its first argument
+func getParameterNameArgIndex(id string) int {
+	if index, ok := parameterNameArgIndexes[id]; ok {
+		return index
+	}
+
+	if strings.HasPrefix(id, "request-header-property-") {
+		return 0
+	}
+
+	return 1
+}
+
+func containsAny(value string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(value, substring) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (ms *mailService) sendEmailToTeamMembers(teamMembersEmails []string, changes checker.Changes, updatedApplication *model.Application, dependingApplication *model.Application) error {
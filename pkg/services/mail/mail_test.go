@@ -0,0 +1,138 @@
+package mail
+
+import (
+	"cosmos-server/pkg/model"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRelevantChanges(t *testing.T) {
+	t.Run("keeps every change to a declared operation without declared fields", filterRelevantChangesWithoutDeclaredFields)
+	t.Run("keeps the changes touching the declared parameters", filterRelevantChangesDeclaredParameters)
+	t.Run("compares the declared parameters to the parameter name only", filterRelevantChangesParameterNameOnly)
+	t.Run("keeps the changes making a parameter or a property required", filterRelevantChangesBecameRequired)
+	t.Run("keeps the changes touching the declared fields", filterRelevantChangesDeclaredFields)
+	t.Run("keeps the changes not scoped to a field", filterRelevantChangesUnscopedChanges)
+}
+
+func getUsersAppEndpointDependencies(details model.EndpointDetails) *model.AppEndpointDependencies {
+	return &model.AppEndpointDependencies{
+		Application: &model.Application{Name: "consumer-application"},
+		Endpoints:   map[string]model.EndpointDetails{model.OperationKey("GET", "/users/{id}"): details},
+	}
+}
+
+func getUsersMatcher() *model.OperationMatcher {
+	paths := openapi3.NewPaths()
+	paths.Set("/users/{userId}", &openapi3.PathItem{Get: &openapi3.Operation{}})
+	paths.Set("/orders", &openapi3.PathItem{Get: &openapi3.Operation{}})
+
+	return model.NewOperationMatcher(&openapi3.T{OpenAPI: "3.0.0", Paths: paths})
+}
+
+func getChangeIDs(changes checker.Changes) []string {
+	ids := make([]string, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.GetId())
+	}
+
+	return ids
+}
+
+func filterRelevantChangesWithoutDeclaredFields(t *testing.T) {
+	ms := &mailService{}
+	changes := checker.Changes{
+		checker.ApiChange{Id: "request-parameter-removed", Args: []any{"query", "expand"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "response-property-removed", Args: []any{"address", "200"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "response-property-removed", Args: []any{"total", "200"}, Operation: "GET", Path: "/orders"},
+	}
+
+	relevantChanges := ms.filterRelevantChanges(changes, getUsersMatcher(), getUsersAppEndpointDependencies(model.EndpointDetails{}))
+
+	require.Equal(t, []string{"request-parameter-removed", "response-property-removed"}, getChangeIDs(relevantChanges))
+}
+
+func filterRelevantChangesDeclaredParameters(t *testing.T) {
+	ms := &mailService{}
+	changes := checker.Changes{
+		checker.ApiChange{Id: "request-parameter-removed", Args: []any{"query", "expand"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-parameter-enum-value-removed", Args: []any{"full", "query", "view"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-parameter-max-length-decreased", Args: []any{"header", "X-Tenant", "64", "32"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-header-property-became-enum", Args: []any{"X-Tenant", "region"}, Operation: "GET", Path: "/users/{userId}"},
+	}
+
+	relevantChanges := ms.filterRelevantChanges(changes, getUsersMatcher(), getUsersAppEndpointDependencies(model.EndpointDetails{
+		Parameters: []string{"view", "x-tenant"},
+	}))
+
+	require.Equal(t, []string{"request-parameter-enum-value-removed", "request-parameter-max-length-decreased", "request-header-property-became-enum"}, getChangeIDs(relevantChanges))
+}
+
+func filterRelevantChangesParameterNameOnly(t *testing.T) {
+	ms := &mailService{}
+	changes := checker.Changes{
+		// The declared parameters are enum values, a location or a default value of undeclared parameters
+		checker.ApiChange{Id: "request-parameter-enum-value-removed", Args: []any{"view", "query", "expand"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-parameter-default-value-changed", Args: []any{"query", "expand", "view", "full"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-parameter-pattern-added", Args: []any{"query", "header", "X-Request-Id"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-header-property-became-enum", Args: []any{"X-Request-Id", "view"}, Operation: "GET", Path: "/users/{userId}"},
+	}
+
+	relevantChanges := ms.filterRelevantChanges(changes, getUsersMatcher(), getUsersAppEndpointDependencies(model.EndpointDetails{
+		Parameters: []string{"view", "query"},
+	}))
+
+	require.Empty(t, relevantChanges)
+}
+
+func filterRelevantChangesBecameRequired(t *testing.T) {
+	ms := &mailService{}
+	changes := checker.Changes{
+		checker.ApiChange{Id: "request-parameter-became-required", Args: []any{"header", "X-Tenant"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-property-became-required", Args: []any{"address/street"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-optional-property-became-required", Args: []any{"nickname"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "new-required-request-property", Args: []any{"tenant"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "request-parameter-became-optional", Args: []any{"query", "expand"}, Operation: "GET", Path: "/users/{userId}"},
+	}
+
+	relevantChanges := ms.filterRelevantChanges(changes, getUsersMatcher(), getUsersAppEndpointDependencies(model.EndpointDetails{
+		Parameters: []string{"view"},
+		Fields:     []string{"email"},
+	}))
+
+	require.Equal(t, []string{"request-parameter-became-required", "request-property-became-required", "request-optional-property-became-required", "new-required-request-property"}, getChangeIDs(relevantChanges))
+}
+
+func filterRelevantChangesDeclaredFields(t *testing.T) {
+	ms := &mailService{}
+	changes := checker.Changes{
+		checker.ApiChange{Id: "response-property-removed", Args: []any{"address/street", "200"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "response-property-type-changed", Args: []any{"roles/items/name", "string", "", "integer", "", "200"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "response-required-property-removed", Args: []any{"email", "200"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "response-property-became-nullable", Args: []any{"address", "200"}, Operation: "GET", Path: "/users/{userId}"},
+	}
+
+	relevantChanges := ms.filterRelevantChanges(changes, getUsersMatcher(), getUsersAppEndpointDependencies(model.EndpointDetails{
+		Fields: []string{"roles.name", "address.city"},
+	}))
+
+	require.Equal(t, []string{"response-property-type-changed", "response-property-became-nullable"}, getChangeIDs(relevantChanges))
+}
+
+func filterRelevantChangesUnscopedChanges(t *testing.T) {
+	ms := &mailService{}
+	changes := checker.Changes{
+		checker.ApiChange{Id: "new-required-request-parameter", Args: []any{"query", "tenant"}, Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "api-removed-without-deprecation", Operation: "GET", Path: "/users/{userId}"},
+		checker.ApiChange{Id: "response-success-status-removed", Args: []any{"200"}, Operation: "GET", Path: "/users/{userId}"},
+	}
+
+	relevantChanges := ms.filterRelevantChanges(changes, getUsersMatcher(), getUsersAppEndpointDependencies(model.EndpointDetails{
+		Fields: []string{"email"},
+	}))
+
+	require.Equal(t, []string{"new-required-request-parameter", "api-removed-without-deprecation", "response-success-status-removed"}, getChangeIDs(relevantChanges))
+}
@@ -0,0 +1,340 @@
+package monitoring
+
+import (
+	"context"
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBitbucketCloudBaseURL = "https://api.bitbucket.org"
+)
+
+// bitbucketService reads files from Bitbucket Cloud or Bitbucket Server. Bitbucket does not expose blob hashes
+// through its REST APIs, so the SHA of a file is the hash of the last commit that modified it on the branch.
+type bitbucketService struct {
+	baseURL    string
+	server     bool
+	httpClient *http.Client
+}
+
+type bitbucketCloudFileHistory struct {
+	Values []struct {
+		Path   string `json:"path"`
+		Size   int    `json:"size"`
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"values"`
+}
+
+type bitbucketCloudBranch struct {
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+type bitbucketCloudCommit struct {
+	Hash    string     `json:"hash"`
+	Message string     `json:"message"`
+	Date    *time.Time `json:"date"`
+	Author  struct {
+		// Raw has the format "Name <email>"
+		Raw  string `json:"raw"`
+		User struct {
+			DisplayName string `json:"display_name"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+type bitbucketServerCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Author  struct {
+		Name         string `json:"name"`
+		EmailAddress string `json:"emailAddress"`
+	} `json:"author"`
+	AuthorTimestamp int64 `json:"authorTimestamp"`
+}
+
+type bitbucketServerCommits struct {
+	Values []struct {
+		ID string `json:"id"`
+	} `json:"values"`
+}
+
+func NewBitbucketCloudService(baseURL string) GitService {
+	if baseURL == "" {
+		baseURL = DefaultBitbucketCloudBaseURL
+	}
+
+	return &bitbucketService{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func NewBitbucketServerService(baseURL string) GitService {
+	return &bitbucketService{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		server:     true,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (b *bitbucketService) GetBranchHead(ctx context.Context, owner, repo, branch, token string) (string, error) {
+	if b.server {
+		commitsURL := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/commits?until=%s&limit=1", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.QueryEscape(branch))
+
+		var commits bitbucketServerCommits
+		found, err := b.getJSON(ctx, commitsURL, token, &commits)
+		if err != nil {
+			return "", err
+		}
+		if !found || len(commits.Values) == 0 {
+			return "", errors.NewNotFoundError(fmt.Sprintf("branch %s not found in repo %s/%s", branch, owner, repo))
+		}
+
+		return commits.Values[0].ID, nil
+	}
+
+	branchURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/refs/branches/%s", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(branch))
+
+	var cloudBranch bitbucketCloudBranch
+	found, err := b.getJSON(ctx, branchURL, token, &cloudBranch)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", errors.NewNotFoundError(fmt.Sprintf("branch %s not found in repo %s/%s", branch, owner, repo))
+	}
+
+	return cloudBranch.Target.Hash, nil
+}
+
+func (b *bitbucketService) GetFilesMetadata(ctx context.Context, owner, repo, ref string, paths []string, token string) (map[string]*model.FileMetadata, error) {
+	filesMetadata := make(map[string]*model.FileMetadata, len(paths))
+
+	for _, filePath := range paths {
+		commitHash, size, err := b.getLastCommitForFile(ctx, owner, repo, ref, filePath, token)
+		if err != nil {
+			return nil, err
+		}
+		if commitHash == "" {
+			continue
+		}
+
+		filesMetadata[filePath] = &model.FileMetadata{
+			Name:       path.Base(filePath),
+			Path:       filePath,
+			Size:       size,
+			SHA:        commitHash,
+			Branch:     ref,
+			Repository: repo,
+			Owner:      owner,
+		}
+	}
+
+	return filesMetadata, nil
+}
+
+func (b *bitbucketService) GetFileWithContent(ctx context.Context, owner, repo, ref, filePath, token string) (*model.FileContent, error) {
+	commitHash, _, err := b.getLastCommitForFile(ctx, owner, repo, ref, filePath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	notFoundErr := errors.NewNotFoundError(fmt.Sprintf("file %s not found in repo %s/%s at %s", filePath, owner, repo, ref))
+	if commitHash == "" {
+		return nil, notFoundErr
+	}
+
+	// The content is read at the commit hash so it always matches the returned SHA, even if the branch moves
+	var contentURL string
+	if b.server {
+		contentURL = fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/raw/%s?at=%s", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), escapeFilePath(filePath), url.QueryEscape(commitHash))
+	} else {
+		contentURL = fmt.Sprintf("%s/2.0/repositories/%s/%s/src/%s/%s", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(commitHash), escapeFilePath(filePath))
+	}
+
+	response, err := b.doRequest(ctx, contentURL, token)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, notFoundErr
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Bitbucket file %s: %v", filePath, err)
+	}
+
+	metadata := model.FileMetadata{
+		Name:       path.Base(filePath),
+		Path:       filePath,
+		Size:       len(content),
+		SHA:        commitHash,
+		Branch:     ref,
+		Repository: repo,
+		Owner:      owner,
+	}
+
+	return &model.FileContent{Metadata: metadata, Content: string(content)}, nil
+}
+
+func (b *bitbucketService) GetCommit(ctx context.Context, owner, repo, sha, token string) (*model.GitCommit, error) {
+	notFoundErr := errors.NewNotFoundError(fmt.Sprintf("commit %s not found in repo %s/%s", sha, owner, repo))
+
+	if b.server {
+		commitURL := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/commits/%s", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(sha))
+
+		var commit bitbucketServerCommit
+		found, err := b.getJSON(ctx, commitURL, token, &commit)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, notFoundErr
+		}
+
+		gitCommit := &model.GitCommit{
+			Sha:         commit.ID,
+			Message:     commit.Message,
+			AuthorName:  commit.Author.Name,
+			AuthorEmail: commit.Author.EmailAddress,
+		}
+		if commit.AuthorTimestamp != 0 {
+			date := time.UnixMilli(commit.AuthorTimestamp)
+			gitCommit.Date = &date
+		}
+
+		return gitCommit, nil
+	}
+
+	commitURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/commit/%s", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(sha))
+
+	var commit bitbucketCloudCommit
+	found, err := b.getJSON(ctx, commitURL, token, &commit)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, notFoundErr
+	}
+
+	authorName, authorEmail := parseRawAuthor(commit.Author.Raw)
+	if commit.Author.User.DisplayName != "" {
+		authorName = commit.Author.User.DisplayName
+	}
+
+	return &model.GitCommit{
+		Sha:         commit.Hash,
+		Message:     commit.Message,
+		AuthorName:  authorName,
+		AuthorEmail: authorEmail,
+		Date:        commit.Date,
+	}, nil
+}
+
+// getLastCommitForFile returns an empty hash when the file does not exist at ref
+func (b *bitbucketService) getLastCommitForFile(ctx context.Context, owner, repo, ref, filePath, token string) (string, int, error) {
+	if b.server {
+		commitsURL := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s/commits?path=%s&until=%s&limit=1", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.QueryEscape(strings.TrimPrefix(filePath, "/")), url.QueryEscape(ref))
+
+		var commits bitbucketServerCommits
+		found, err := b.getJSON(ctx, commitsURL, token, &commits)
+		if err != nil || !found || len(commits.Values) == 0 {
+			return "", 0, err
+		}
+
+		return commits.Values[0].ID, 0, nil
+	}
+
+	historyURL := fmt.Sprintf("%s/2.0/repositories/%s/%s/filehistory/%s/%s?pagelen=1", b.baseURL, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(ref), escapeFilePath(filePath))
+
+	var history bitbucketCloudFileHistory
+	found, err := b.getJSON(ctx, historyURL, token, &history)
+	if err != nil || !found || len(history.Values) == 0 {
+		return "", 0, err
+	}
+
+	return history.Values[0].Commit.Hash, history.Values[0].Size, nil
+}
+
+func (b *bitbucketService) getJSON(ctx context.Context, requestURL, token string, target any) (bool, error) {
+	response, err := b.doRequest(ctx, requestURL, token)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return false, fmt.Errorf("failed to decode Bitbucket response: %v", err)
+	}
+
+	return true, nil
+}
+
+// doRequest returns the response for successful and not found requests, any other status is an error
+func (b *bitbucketService) doRequest(ctx context.Context, requestURL, token string) (*http.Response, error) {
+	if b.baseURL == "" {
+		return nil, fmt.Errorf("Bitbucket Server base URL is not configured")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// App passwords are stored as "username:app_password", anything else is used as an access token
+	if username, password, isAppPassword := strings.Cut(token, ":"); isAppPassword {
+		request.SetBasicAuth(username, password)
+	} else if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusBadRequest && response.StatusCode != http.StatusNotFound {
+		response.Body.Close()
+		return nil, model.NewGitStatusError(response.StatusCode, fmt.Errorf("Bitbucket returned status %d for %s", response.StatusCode, request.URL.Path))
+	}
+
+	return response, nil
+}
+
+// parseRawAuthor splits an author with the format "Name <email>", the email is empty when it is missing
+func parseRawAuthor(rawAuthor string) (string, string) {
+	name, email, found := strings.Cut(rawAuthor, "<")
+	if !found {
+		return strings.TrimSpace(rawAuthor), ""
+	}
+
+	return strings.TrimSpace(name), strings.TrimSuffix(strings.TrimSpace(email), ">")
+}
+
+func escapeFilePath(filePath string) string {
+	segments := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,206 @@
+package monitoring
+
+import (
+	"context"
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/model"
+	errorUtils "errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const mockedBitbucketCommitHash = "0123456789abcdef0123456789abcdef01234567"
+
+func TestBitbucketService(t *testing.T) {
+	t.Run("bitbucket cloud service - get file with content", bitbucketCloudServiceGetFileWithContent)
+	t.Run("bitbucket cloud service - get commit", bitbucketCloudServiceGetCommit)
+	t.Run("bitbucket server service - get file with content", bitbucketServerServiceGetFileWithContent)
+	t.Run("bitbucket server service - get branch head", bitbucketServerServiceGetBranchHead)
+	t.Run("bitbucket service - app password is sent with basic auth", bitbucketServiceAppPassword)
+	t.Run("bitbucket service - access token is sent as bearer token", bitbucketServiceAccessToken)
+	t.Run("bitbucket service - get files metadata skips missing files", bitbucketServiceGetFilesMetadataNotFound)
+	t.Run("bitbucket service - get file with content not found", bitbucketServiceGetFileWithContentNotFound)
+	t.Run("bitbucket service - error status", bitbucketServiceErrorStatus)
+	t.Run("bitbucket service - parse raw author", bitbucketServiceParseRawAuthor)
+}
+
+func bitbucketCloudServiceGetFileWithContent(t *testing.T) {
+	requests := make([]string, 0)
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		if r.URL.Query().Get("pagelen") != "" {
+			_, _ = w.Write([]byte(`{"values": [{"path": "docs/open api.json", "size": 27, "commit": {"hash": "` + mockedBitbucketCommitHash + `"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(mockedOpenClientContent))
+	})
+
+	service := NewBitbucketCloudService(server.URL + "/")
+
+	fileContent, err := service.GetFileWithContent(context.TODO(), "test-workspace", "test-repo", "feature/login", "/docs/open api.json", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/2.0/repositories/test-workspace/test-repo/filehistory/feature%2Flogin/docs/open%20api.json?pagelen=1",
+		"/2.0/repositories/test-workspace/test-repo/src/" + mockedBitbucketCommitHash + "/docs/open%20api.json?",
+	}, requests)
+	require.Equal(t, mockedOpenClientContent, fileContent.Content)
+	require.Equal(t, model.FileMetadata{
+		Name:       "open api.json",
+		Path:       "/docs/open api.json",
+		Size:       len(mockedOpenClientContent),
+		SHA:        mockedBitbucketCommitHash,
+		Branch:     "feature/login",
+		Repository: "test-repo",
+		Owner:      "test-workspace",
+	}, fileContent.Metadata)
+}
+
+func bitbucketCloudServiceGetCommit(t *testing.T) {
+	var escapedPath string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		escapedPath = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`{"hash": "` + mockedBitbucketCommitHash + `", "message": "Add orders endpoint", "date": "2025-01-07T12:00:00Z", "author": {"raw": "Jane Doe <jane@test.com>", "user": {}}}`))
+	})
+
+	service := NewBitbucketCloudService(server.URL)
+
+	commit, err := service.GetCommit(context.TODO(), "test-workspace", "test-repo", mockedBitbucketCommitHash, "")
+	require.NoError(t, err)
+	require.Equal(t, "/2.0/repositories/test-workspace/test-repo/commit/"+mockedBitbucketCommitHash, escapedPath)
+	require.Equal(t, mockedBitbucketCommitHash, commit.Sha)
+	require.Equal(t, "Add orders endpoint", commit.Message)
+	require.Equal(t, "Jane Doe", commit.AuthorName)
+	require.Equal(t, "jane@test.com", commit.AuthorEmail)
+	require.NotNil(t, commit.Date)
+}
+
+func bitbucketServerServiceGetFileWithContent(t *testing.T) {
+	requests := make([]string, 0)
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		if r.URL.Query().Get("until") != "" {
+			_, _ = w.Write([]byte(`{"values": [{"id": "` + mockedBitbucketCommitHash + `"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(mockedOpenClientContent))
+	})
+
+	service := NewBitbucketServerService(server.URL)
+
+	fileContent, err := service.GetFileWithContent(context.TODO(), "TEST", "test-repo", "release/1.0", "docs/open api.json", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/rest/api/1.0/projects/TEST/repos/test-repo/commits?path=docs%2Fopen+api.json&until=release%2F1.0&limit=1",
+		"/rest/api/1.0/projects/TEST/repos/test-repo/raw/docs/open%20api.json?at=" + mockedBitbucketCommitHash,
+	}, requests)
+	require.Equal(t, mockedOpenClientContent, fileContent.Content)
+	require.Equal(t, mockedBitbucketCommitHash, fileContent.Metadata.SHA)
+}
+
+func bitbucketServerServiceGetBranchHead(t *testing.T) {
+	var requestURI string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.URL.EscapedPath() + "?" + r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"values": [{"id": "` + mockedBitbucketCommitHash + `"}]}`))
+	})
+
+	service := NewBitbucketServerService(server.URL)
+
+	commitSha, err := service.GetBranchHead(context.TODO(), "TEST", "test-repo", "release/1.0", "")
+	require.NoError(t, err)
+	require.Equal(t, mockedBitbucketCommitHash, commitSha)
+	require.Equal(t, "/rest/api/1.0/projects/TEST/repos/test-repo/commits?until=release%2F1.0&limit=1", requestURI)
+}
+
+func bitbucketServiceAppPassword(t *testing.T) {
+	var username, password string
+	var hasBasicAuth bool
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		username, password, hasBasicAuth = r.BasicAuth()
+		_, _ = w.Write([]byte(`{"target": {"hash": "` + mockedBitbucketCommitHash + `"}}`))
+	})
+
+	service := NewBitbucketCloudService(server.URL)
+
+	_, err := service.GetBranchHead(context.TODO(), "test-workspace", "test-repo", "main", "test-user:app-password")
+	require.NoError(t, err)
+	require.True(t, hasBasicAuth)
+	require.Equal(t, "test-user", username)
+	require.Equal(t, "app-password", password)
+}
+
+func bitbucketServiceAccessToken(t *testing.T) {
+	var authorization string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"target": {"hash": "` + mockedBitbucketCommitHash + `"}}`))
+	})
+
+	service := NewBitbucketCloudService(server.URL)
+
+	commitSha, err := service.GetBranchHead(context.TODO(), "test-workspace", "test-repo", "main", "access-token")
+	require.NoError(t, err)
+	require.Equal(t, mockedBitbucketCommitHash, commitSha)
+	require.Equal(t, "Bearer access-token", authorization)
+}
+
+func bitbucketServiceGetFilesMetadataNotFound(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/2.0/repositories/test-workspace/test-repo/filehistory/main/docs/openapi.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"values": [{"path": "docs/openclient.json", "size": 27, "commit": {"hash": "` + mockedBitbucketCommitHash + `"}}]}`))
+	})
+
+	service := NewBitbucketCloudService(server.URL)
+
+	filesMetadata, err := service.GetFilesMetadata(context.TODO(), "test-workspace", "test-repo", "main", []string{"docs/openclient.json", "docs/openapi.json"}, "")
+	require.NoError(t, err)
+	require.Len(t, filesMetadata, 1)
+	require.Equal(t, mockedBitbucketCommitHash, filesMetadata["docs/openclient.json"].SHA)
+	require.Equal(t, 27, filesMetadata["docs/openclient.json"].Size)
+}
+
+func bitbucketServiceGetFileWithContentNotFound(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// An existing branch without any commit for the path
+		_, _ = w.Write([]byte(`{"values": []}`))
+	})
+
+	service := NewBitbucketServerService(server.URL)
+
+	_, err := service.GetFileWithContent(context.TODO(), "TEST", "test-repo", "main", "docs/openclient.json", "")
+	require.Error(t, err)
+
+	var programErr errors.ProgramError
+	require.True(t, errorUtils.As(err, &programErr))
+	require.Equal(t, errors.NotFound, programErr.Type())
+}
+
+func bitbucketServiceErrorStatus(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	service := NewBitbucketCloudService(server.URL)
+
+	_, err := service.GetFilesMetadata(context.TODO(), "test-workspace", "test-repo", "main", []string{"docs/openclient.json"}, "")
+	require.Error(t, err)
+
+	var statusErr *model.GitStatusError
+	require.True(t, errorUtils.As(err, &statusErr))
+	require.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+}
+
+func bitbucketServiceParseRawAuthor(t *testing.T) {
+	name, email := parseRawAuthor("Jane Doe <jane@test.com>")
+	require.Equal(t, "Jane Doe", name)
+	require.Equal(t, "jane@test.com", email)
+
+	name, email = parseRawAuthor(" Jane Doe ")
+	require.Equal(t, "Jane Doe", name)
+	require.Empty(t, email)
+}
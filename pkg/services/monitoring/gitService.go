@@ -2,11 +2,13 @@ package monitoring
 
 import (
 	"context"
-	"cosmos-server/pkg/errors"
 	"cosmos-server/pkg/model"
+	"errors"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/go-github/v74/github"
 	"golang.org/x/oauth2"
@@ -15,28 +17,38 @@ import (
 //go:generate mockgen -destination=./mock/gitService_mock.go -package=mock cosmos-server/pkg/services/monitoring GitService
 
 type GitService interface {
-	GetFileMetadata(ctx context.Context, owner, repo, branch, path, token string) (*model.FileMetadata, error)
-	GetFileWithContent(ctx context.Context, owner, repo, branch, path, token string) (*model.FileContent, error)
+	GetBranchHead(ctx context.Context, owner, repo, branch, token string) (string, error)
+	// GetFilesMetadata returns the metadata of the given paths at ref, keyed by path. Paths that do not exist are left out.
+	GetFilesMetadata(ctx context.Context, owner, repo, ref string, paths []string, token string) (map[string]*model.FileMetadata, error)
+	GetFileWithContent(ctx context.Context, owner, repo, ref, path, token string) (*model.FileContent, error)
+	GetCommit(ctx context.Context, owner, repo, sha, token string) (*model.GitCommit, error)
+}
+
+// RateLimitedGitService is implemented by the git services that track the API quota of the tokens they use
+type RateLimitedGitService interface {
+	GetRateLimits() []*model.GitRateLimit
 }
 
 type githubService struct {
-	defaultToken  string
 	clients       sync.Map // map[string]*github.Client
 	defaultClient *github.Client
+	rateLimits    *githubRateLimits
 }
 
 func NewGithubService() GitService {
-	var httpClient *http.Client
+	rateLimits := newGithubRateLimits()
 
+	httpClient := &http.Client{}
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
 		src := oauth2.StaticTokenSource(
 			&oauth2.Token{AccessToken: token},
 		)
 		httpClient = oauth2.NewClient(context.Background(), src)
 	}
+	httpClient.Transport = newGithubTransport(httpClient.Transport, githubDefaultTokenName, rateLimits)
 
 	client := github.NewClient(httpClient)
-	return &githubService{defaultClient: client}
+	return &githubService{defaultClient: client, rateLimits: rateLimits}
 }
 
 func (g *githubService) getClient(token string) *github.Client {
@@ -52,38 +64,61 @@ func (g *githubService) getClient(token string) *github.Client {
 		&oauth2.Token{AccessToken: token},
 	)
 	httpClient := oauth2.NewClient(context.Background(), src)
+	httpClient.Transport = newGithubTransport(httpClient.Transport, getGithubTokenName(token), g.rateLimits)
 	client := github.NewClient(httpClient)
 	g.clients.Store(token, client)
 	return client
 }
 
-func (g *githubService) GetFileMetadata(ctx context.Context, owner, repo, branch, path, token string) (*model.FileMetadata, error) {
-	tree, _, err := g.getClient(token).Git.GetTree(ctx, owner, repo, branch, true)
+func (g *githubService) GetRateLimits() []*model.GitRateLimit {
+	return g.rateLimits.list()
+}
+
+func (g *githubService) GetBranchHead(ctx context.Context, owner, repo, branch, token string) (string, error) {
+	ref, _, err := g.getClient(token).Git.GetRef(ctx, owner, repo, "heads/"+branch)
 	if err != nil {
-		return nil, err
+		return "", toGithubError(err)
+	}
+
+	return ref.GetObject().GetSHA(), nil
+}
+
+func (g *githubService) GetFilesMetadata(ctx context.Context, owner, repo, ref string, paths []string, token string) (map[string]*model.FileMetadata, error) {
+	tree, _, err := g.getClient(token).Git.GetTree(ctx, owner, repo, ref, true)
+	if err != nil {
+		return nil, toGithubError(err)
+	}
+
+	requestedPaths := make(map[string]string, len(paths))
+	for _, path := range paths {
+		requestedPaths[strings.TrimPrefix(path, "/")] = path
 	}
 
+	filesMetadata := make(map[string]*model.FileMetadata, len(paths))
 	for _, entry := range tree.Entries {
-		if entry.GetPath() == path {
-			return &model.FileMetadata{
-				Name:       entry.GetPath(),
-				Path:       entry.GetPath(),
-				Size:       entry.GetSize(),
-				SHA:        entry.GetSHA(),
-				Branch:     branch,
-				Repository: repo,
-				Owner:      owner,
-			}, nil
+		requestedPath, ok := requestedPaths[entry.GetPath()]
+		if !ok || entry.GetType() != "blob" {
+			continue
+		}
+
+		filesMetadata[requestedPath] = &model.FileMetadata{
+			Name:       entry.GetPath(),
+			Path:       entry.GetPath(),
+			Size:       entry.GetSize(),
+			SHA:        entry.GetSHA(),
+			Branch:     ref,
+			Repository: repo,
+			Owner:      owner,
 		}
 	}
 
-	return nil, errors.NewNotFoundError("file %s not found in repo %s/%s on branch %s", path, owner, repo, branch)
+	return filesMetadata, nil
 }
 
-func (g *githubService) GetFileWithContent(ctx context.Context, owner, repo, branch, path, token string) (*model.FileContent, error) {
-	file, _, _, err := g.getClient(token).Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+func (g *githubService) GetFileWithContent(ctx context.Context, owner, repo, ref, path, token string) (*model.FileContent, error) {
+	file, _, _, err := g.getClient(token).Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
 	if err != nil {
-		return nil, err
+		return nil, toGithubError(err)
 	}
 
 	content, err := file.GetContent()
@@ -96,10 +131,55 @@ func (g *githubService) GetFileWithContent(ctx context.Context, owner, repo, bra
 		Path:       file.GetPath(),
 		Size:       file.GetSize(),
 		SHA:        file.GetSHA(),
-		Branch:     branch,
+		Branch:     ref,
 		Repository: repo,
 		Owner:      owner,
 	}
 
 	return &model.FileContent{Metadata: metadata, Content: content}, nil
 }
+
+func (g *githubService) GetCommit(ctx context.Context, owner, repo, sha, token string) (*model.GitCommit, error) {
+	commit, _, err := g.getClient(token).Git.GetCommit(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, toGithubError(err)
+	}
+
+	gitCommit := &model.GitCommit{
+		Sha:         commit.GetSHA(),
+		Message:     commit.GetMessage(),
+		AuthorName:  commit.GetAuthor().GetName(),
+		AuthorEmail: commit.GetAuthor().GetEmail(),
+	}
+	if commit.GetAuthor().Date != nil {
+		date := commit.GetAuthor().GetDate().Time
+		gitCommit.Date = &date
+	}
+
+	return gitCommit, nil
+}
+
+// toGithubError turns the errors of the GitHub client into model errors, rate limits are kept apart so the sentinel can
+// reschedule the sync instead of failing it
+func toGithubError(err error) error {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return &model.RateLimitError{Provider: model.GitProviderGithub, ResetAt: rateLimitErr.Rate.Reset.Time}
+	}
+
+	var abuseRateLimitErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseRateLimitErr) {
+		retryAfter := abuseRateLimitErr.GetRetryAfter()
+		if retryAfter == 0 {
+			retryAfter = githubDefaultRetryAfter
+		}
+		return &model.RateLimitError{Provider: model.GitProviderGithub, ResetAt: time.Now().Add(retryAfter)}
+	}
+
+	var errorResponse *github.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Response != nil {
+		return model.NewGitStatusError(errorResponse.Response.StatusCode, err)
+	}
+
+	return err
+}
@@ -0,0 +1,285 @@
+package monitoring
+
+import (
+	"bytes"
+	"container/list"
+	"cosmos-server/pkg/model"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	githubDefaultTokenName   = "default"
+	githubMaxCachedResponses = 500
+	// githubMaxCachedBytes bounds the bodies cached per token, tree and content responses of large repositories are big
+	githubMaxCachedBytes    = 32 << 20
+	githubRateLimitResource = "core"
+	githubDefaultRetryAfter = time.Minute
+
+	headerETag               = "ETag"
+	headerIfNoneMatch        = "If-None-Match"
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitUsed      = "X-RateLimit-Used"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRateLimitResource  = "X-RateLimit-Resource"
+)
+
+// githubRateLimits keeps the last known core quota of every token used against the GitHub API
+type githubRateLimits struct {
+	mutex  sync.Mutex
+	limits map[string]*model.GitRateLimit
+	now    func() time.Time
+}
+
+// githubTransport sends conditional requests for the responses it has already seen, GitHub does not count 304
+// responses against the quota, and stops calling GitHub for a token whose quota is exhausted until it resets
+type githubTransport struct {
+	base       http.RoundTripper
+	tokenName  string
+	rateLimits *githubRateLimits
+	cache      *githubResponseCache
+}
+
+type githubResponseCache struct {
+	mutex    sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List
+	size     int
+	bytes    int
+	maxBytes int
+}
+
+type githubCachedResponse struct {
+	key    string
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+func newGithubRateLimits() *githubRateLimits {
+	return &githubRateLimits{
+		limits: make(map[string]*model.GitRateLimit),
+		now:    time.Now,
+	}
+}
+
+func newGithubTransport(base http.RoundTripper, tokenName string, rateLimits *githubRateLimits) *githubTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &githubTransport{
+		base:       base,
+		tokenName:  tokenName,
+		rateLimits: rateLimits,
+		cache:      newGithubResponseCache(githubMaxCachedResponses, githubMaxCachedBytes),
+	}
+}
+
+// getGithubTokenName identifies a token without exposing it
+func getGithubTokenName(token string) string {
+	if token == "" {
+		return githubDefaultTokenName
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	return "sha256:" + hex.EncodeToString(hash[:])[:12]
+}
+
+func (t *githubTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if resetAt, exhausted := t.rateLimits.exhausted(t.tokenName); exhausted {
+		return nil, &model.RateLimitError{Provider: model.GitProviderGithub, ResetAt: resetAt}
+	}
+
+	var cachedResponse *githubCachedResponse
+	if request.Method == http.MethodGet {
+		cachedResponse = t.cache.get(request.URL.String())
+		if cachedResponse != nil {
+			request = request.Clone(request.Context())
+			request.Header.Set(headerIfNoneMatch, cachedResponse.etag)
+		}
+	}
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	t.rateLimits.update(t.tokenName, response)
+
+	if response.StatusCode == http.StatusNotModified && cachedResponse != nil {
+		response.Body.Close()
+		return cachedResponse.toResponse(request, response), nil
+	}
+
+	etag := response.Header.Get(headerETag)
+	if request.Method != http.MethodGet || response.StatusCode != http.StatusOK || etag == "" {
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.cache.add(&githubCachedResponse{
+		key:    request.URL.String(),
+		etag:   etag,
+		header: response.Header.Clone(),
+		body:   body,
+	})
+
+	return response, nil
+}
+
+func (c *githubCachedResponse) toResponse(request *http.Request, notModifiedResponse *http.Response) *http.Response {
+	header := c.header.Clone()
+	// The rate limit headers of the 304 response are the current ones
+	for _, name := range []string{headerRateLimitLimit, headerRateLimitRemaining, headerRateLimitUsed, headerRateLimitReset, headerRateLimitResource} {
+		if value := notModifiedResponse.Header.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(http.StatusOK),
+		StatusCode:    http.StatusOK,
+		Proto:         notModifiedResponse.Proto,
+		ProtoMajor:    notModifiedResponse.ProtoMajor,
+		ProtoMinor:    notModifiedResponse.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       request,
+	}
+}
+
+func (r *githubRateLimits) exhausted(tokenName string) (time.Time, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rateLimit, ok := r.limits[tokenName]
+	if !ok || !rateLimit.Exhausted(r.now()) {
+		return time.Time{}, false
+	}
+
+	return rateLimit.ResetAt, true
+}
+
+func (r *githubRateLimits) update(tokenName string, response *http.Response) {
+	resource := response.Header.Get(headerRateLimitResource)
+	if resource != "" && resource != githubRateLimitResource {
+		return
+	}
+
+	remaining, remainingErr := strconv.Atoi(response.Header.Get(headerRateLimitRemaining))
+	retryAfter, retryAfterErr := strconv.Atoi(response.Header.Get(headerRetryAfter))
+	// Secondary rate limits are answered with a Retry-After header and leave the primary quota untouched
+	secondaryRateLimited := retryAfterErr == nil && (response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusTooManyRequests)
+	if remainingErr != nil && !secondaryRateLimited {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+
+	rateLimit, ok := r.limits[tokenName]
+	if !ok {
+		rateLimit = &model.GitRateLimit{Provider: model.GitProviderGithub, Token: tokenName}
+		r.limits[tokenName] = rateLimit
+	}
+	rateLimit.UpdatedAt = now
+
+	if remainingErr == nil {
+		rateLimit.Remaining = remaining
+		rateLimit.Limit, _ = strconv.Atoi(response.Header.Get(headerRateLimitLimit))
+		rateLimit.Used, _ = strconv.Atoi(response.Header.Get(headerRateLimitUsed))
+		if reset, err := strconv.ParseInt(response.Header.Get(headerRateLimitReset), 10, 64); err == nil {
+			rateLimit.ResetAt = time.Unix(reset, 0)
+		}
+	}
+
+	if secondaryRateLimited {
+		rateLimit.Remaining = 0
+		rateLimit.ResetAt = now.Add(time.Duration(retryAfter) * time.Second)
+	}
+}
+
+func (r *githubRateLimits) list() []*model.GitRateLimit {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rateLimits := make([]*model.GitRateLimit, 0, len(r.limits))
+	for _, rateLimit := range r.limits {
+		rateLimitCopy := *rateLimit
+		rateLimits = append(rateLimits, &rateLimitCopy)
+	}
+
+	sort.Slice(rateLimits, func(i, j int) bool {
+		return rateLimits[i].Token < rateLimits[j].Token
+	})
+
+	return rateLimits
+}
+
+func newGithubResponseCache(size, maxBytes int) *githubResponseCache {
+	return &githubResponseCache{
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		size:     size,
+		maxBytes: maxBytes,
+	}
+}
+
+func (c *githubResponseCache) get(key string) *githubCachedResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*githubCachedResponse)
+}
+
+// add evicts the least recently used responses once the cache holds too many responses or bytes, a response bigger
+// than the whole cache is not kept
+func (c *githubResponseCache) add(response *githubCachedResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[response.key]; ok {
+		c.remove(element)
+	}
+
+	if len(response.body) > c.maxBytes {
+		return
+	}
+
+	c.entries[response.key] = c.order.PushFront(response)
+	c.bytes += len(response.body)
+
+	for c.order.Len() > c.size || c.bytes > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *githubResponseCache) remove(element *list.Element) {
+	response := c.order.Remove(element).(*githubCachedResponse)
+	delete(c.entries, response.key)
+	c.bytes -= len(response.body)
+}
@@ -0,0 +1,153 @@
+package monitoring
+
+import (
+	"cosmos-server/pkg/model"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGithubTransport(t *testing.T) {
+	t.Run("github transport - conditional request returns cached response", githubTransportConditionalRequest)
+	t.Run("github transport - cache is bounded by its bytes", githubTransportCacheBoundedByBytes)
+	t.Run("github transport - rate limit is tracked per token", githubTransportRateLimitTracked)
+	t.Run("github transport - exhausted token is paused", githubTransportExhaustedTokenPaused)
+	t.Run("github transport - secondary rate limit pauses token", githubTransportSecondaryRateLimit)
+}
+
+func newGitTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func setRateLimitHeaders(w http.ResponseWriter, remaining int, resetAt time.Time) {
+	w.Header().Set(headerRateLimitLimit, "5000")
+	w.Header().Set(headerRateLimitRemaining, strconv.Itoa(remaining))
+	w.Header().Set(headerRateLimitUsed, strconv.Itoa(5000-remaining))
+	w.Header().Set(headerRateLimitReset, strconv.FormatInt(resetAt.Unix(), 10))
+	w.Header().Set(headerRateLimitResource, githubRateLimitResource)
+}
+
+func githubTransportConditionalRequest(t *testing.T) {
+	requests := 0
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		setRateLimitHeaders(w, 4999, time.Now().Add(time.Hour))
+		if r.Header.Get(headerIfNoneMatch) == `"etag-1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(headerETag, `"etag-1"`)
+		_, _ = w.Write([]byte(`{"sha": "abc123"}`))
+	})
+
+	client := &http.Client{Transport: newGithubTransport(nil, githubDefaultTokenName, newGithubRateLimits())}
+
+	for range 2 {
+		response, err := client.Get(server.URL + "/repos/test-owner/test-repo/git/ref/heads/main")
+		require.NoError(t, err)
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, `{"sha": "abc123"}`, string(body))
+	}
+
+	require.Equal(t, 2, requests)
+}
+
+func githubTransportCacheBoundedByBytes(t *testing.T) {
+	cache := newGithubResponseCache(10, 10)
+
+	cache.add(&githubCachedResponse{key: "first", etag: `"etag-1"`, body: []byte("12345")})
+	cache.add(&githubCachedResponse{key: "second", etag: `"etag-2"`, body: []byte("12345")})
+	require.NotNil(t, cache.get("first"))
+	require.NotNil(t, cache.get("second"))
+
+	// The least recently used response is evicted to make room
+	cache.add(&githubCachedResponse{key: "third", etag: `"etag-3"`, body: []byte("123")})
+	require.Nil(t, cache.get("first"))
+	require.NotNil(t, cache.get("second"))
+	require.NotNil(t, cache.get("third"))
+	require.Equal(t, 8, cache.bytes)
+
+	// A response bigger than the cache is not kept, nor its previous version
+	cache.add(&githubCachedResponse{key: "second", etag: `"etag-4"`, body: []byte("12345678901")})
+	require.Nil(t, cache.get("second"))
+	require.NotNil(t, cache.get("third"))
+	require.Equal(t, 3, cache.bytes)
+}
+
+func githubTransportRateLimitTracked(t *testing.T) {
+	resetAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		setRateLimitHeaders(w, 4200, resetAt)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	rateLimits := newGithubRateLimits()
+	client := &http.Client{Transport: newGithubTransport(nil, getGithubTokenName("secret-token"), rateLimits)}
+
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	limits := rateLimits.list()
+	require.Len(t, limits, 1)
+	require.Equal(t, getGithubTokenName("secret-token"), limits[0].Token)
+	require.NotContains(t, limits[0].Token, "secret-token")
+	require.Equal(t, 5000, limits[0].Limit)
+	require.Equal(t, 4200, limits[0].Remaining)
+	require.Equal(t, 800, limits[0].Used)
+	require.True(t, resetAt.Equal(limits[0].ResetAt))
+}
+
+func githubTransportExhaustedTokenPaused(t *testing.T) {
+	requests := 0
+	resetAt := time.Now().Add(time.Hour)
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		setRateLimitHeaders(w, 0, resetAt)
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	client := &http.Client{Transport: newGithubTransport(nil, githubDefaultTokenName, newGithubRateLimits())}
+
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	_, err = client.Get(server.URL)
+	require.Error(t, err)
+
+	var rateLimitErr *model.RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	require.Equal(t, resetAt.Unix(), rateLimitErr.ResetAt.Unix())
+	require.Equal(t, 1, requests)
+}
+
+func githubTransportSecondaryRateLimit(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRetryAfter, "60")
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	rateLimits := newGithubRateLimits()
+	client := &http.Client{Transport: newGithubTransport(nil, githubDefaultTokenName, rateLimits)}
+
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	resetAt, exhausted := rateLimits.exhausted(githubDefaultTokenName)
+	require.True(t, exhausted)
+	require.WithinDuration(t, time.Now().Add(time.Minute), resetAt, 5*time.Second)
+}
@@ -0,0 +1,220 @@
+package monitoring
+
+import (
+	"context"
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/model"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultGitlabBaseURL = "https://gitlab.com"
+
+	gitlabTokenHeader    = "PRIVATE-TOKEN"
+	gitlabBlobIDHeader   = "X-Gitlab-Blob-Id"
+	gitlabFileNameHeader = "X-Gitlab-File-Name"
+	gitlabFilePathHeader = "X-Gitlab-File-Path"
+	gitlabSizeHeader     = "X-Gitlab-Size"
+)
+
+type gitlabService struct {
+	baseURL      string
+	defaultToken string
+	httpClient   *http.Client
+}
+
+type gitlabBranch struct {
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+type gitlabCommit struct {
+	ID           string     `json:"id"`
+	Message      string     `json:"message"`
+	AuthorName   string     `json:"author_name"`
+	AuthorEmail  string     `json:"author_email"`
+	AuthoredDate *time.Time `json:"authored_date"`
+}
+
+type gitlabFile struct {
+	FileName string `json:"file_name"`
+	FilePath string `json:"file_path"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+	BlobID   string `json:"blob_id"`
+}
+
+func NewGitlabService(baseURL, defaultToken string) GitService {
+	if baseURL == "" {
+		baseURL = DefaultGitlabBaseURL
+	}
+
+	return &gitlabService{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		defaultToken: defaultToken,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (g *gitlabService) GetBranchHead(ctx context.Context, owner, repo, branch, token string) (string, error) {
+	requestURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/branches/%s", g.baseURL, g.getProjectID(owner, repo), url.PathEscape(branch))
+
+	response, err := g.doRequest(ctx, http.MethodGet, requestURL, token)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return "", errors.NewNotFoundError(fmt.Sprintf("branch %s not found in repo %s/%s", branch, owner, repo))
+	}
+
+	var gitlabBranch gitlabBranch
+	if err := json.NewDecoder(response.Body).Decode(&gitlabBranch); err != nil {
+		return "", fmt.Errorf("failed to decode GitLab branch %s: %v", branch, err)
+	}
+
+	return gitlabBranch.Commit.ID, nil
+}
+
+// GitLab has no cheap way to list a whole tree with blob ids, so each file is looked up with a HEAD request instead
+func (g *gitlabService) GetFilesMetadata(ctx context.Context, owner, repo, ref string, paths []string, token string) (map[string]*model.FileMetadata, error) {
+	filesMetadata := make(map[string]*model.FileMetadata, len(paths))
+
+	for _, path := range paths {
+		response, err := g.doRequest(ctx, http.MethodHead, g.getFileURL(owner, repo, ref, path), token)
+		if err != nil {
+			return nil, err
+		}
+		response.Body.Close()
+
+		if response.StatusCode == http.StatusNotFound {
+			continue
+		}
+
+		size, _ := strconv.Atoi(response.Header.Get(gitlabSizeHeader))
+
+		filesMetadata[path] = &model.FileMetadata{
+			Name:       response.Header.Get(gitlabFileNameHeader),
+			Path:       response.Header.Get(gitlabFilePathHeader),
+			Size:       size,
+			SHA:        response.Header.Get(gitlabBlobIDHeader),
+			Branch:     ref,
+			Repository: repo,
+			Owner:      owner,
+		}
+	}
+
+	return filesMetadata, nil
+}
+
+func (g *gitlabService) GetFileWithContent(ctx context.Context, owner, repo, ref, path, token string) (*model.FileContent, error) {
+	response, err := g.doRequest(ctx, http.MethodGet, g.getFileURL(owner, repo, ref, path), token)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("file %s not found in repo %s/%s at %s", path, owner, repo, ref))
+	}
+
+	var file gitlabFile
+	if err := json.NewDecoder(response.Body).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab file %s: %v", path, err)
+	}
+
+	content := file.Content
+	if file.Encoding == "base64" {
+		decodedContent, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode content of GitLab file %s: %v", path, err)
+		}
+		content = string(decodedContent)
+	}
+
+	metadata := model.FileMetadata{
+		Name:       file.FileName,
+		Path:       file.FilePath,
+		Size:       file.Size,
+		SHA:        file.BlobID,
+		Branch:     ref,
+		Repository: repo,
+		Owner:      owner,
+	}
+
+	return &model.FileContent{Metadata: metadata, Content: content}, nil
+}
+
+func (g *gitlabService) GetCommit(ctx context.Context, owner, repo, sha, token string) (*model.GitCommit, error) {
+	requestURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/commits/%s", g.baseURL, g.getProjectID(owner, repo), url.PathEscape(sha))
+
+	response, err := g.doRequest(ctx, http.MethodGet, requestURL, token)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("commit %s not found in repo %s/%s", sha, owner, repo))
+	}
+
+	var commit gitlabCommit
+	if err := json.NewDecoder(response.Body).Decode(&commit); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab commit %s: %v", sha, err)
+	}
+
+	return &model.GitCommit{
+		Sha:         commit.ID,
+		Message:     commit.Message,
+		AuthorName:  commit.AuthorName,
+		AuthorEmail: commit.AuthorEmail,
+		Date:        commit.AuthoredDate,
+	}, nil
+}
+
+// GitLab identifies projects by their URL-encoded full path, which may include nested groups in the owner
+func (g *gitlabService) getProjectID(owner, repo string) string {
+	return url.PathEscape(owner + "/" + repo)
+}
+
+func (g *gitlabService) getFileURL(owner, repo, ref, path string) string {
+	filePath := url.PathEscape(strings.TrimPrefix(path, "/"))
+	return fmt.Sprintf("%s/api/v4/projects/%s/repository/files/%s?ref=%s", g.baseURL, g.getProjectID(owner, repo), filePath, url.QueryEscape(ref))
+}
+
+// doRequest returns the response for successful and not found requests, any other status is an error
+func (g *gitlabService) doRequest(ctx context.Context, method, requestURL, token string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		token = g.defaultToken
+	}
+	if token != "" {
+		request.Header.Set(gitlabTokenHeader, token)
+	}
+
+	response, err := g.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusBadRequest && response.StatusCode != http.StatusNotFound {
+		response.Body.Close()
+		return nil, model.NewGitStatusError(response.StatusCode, fmt.Errorf("GitLab returned status %d for %s", response.StatusCode, request.URL.Path))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,161 @@
+package monitoring
+
+import (
+	"context"
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/model"
+	"encoding/base64"
+	errorUtils "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitlabService(t *testing.T) {
+	t.Run("gitlab service - nested group project id is escaped", gitlabServiceNestedGroupProjectID)
+	t.Run("gitlab service - get files metadata from head headers", gitlabServiceGetFilesMetadata)
+	t.Run("gitlab service - get files metadata skips missing files", gitlabServiceGetFilesMetadataNotFound)
+	t.Run("gitlab service - get file with content decodes base64", gitlabServiceGetFileWithContent)
+	t.Run("gitlab service - get file with content not found", gitlabServiceGetFileWithContentNotFound)
+	t.Run("gitlab service - default token is used without application token", gitlabServiceDefaultToken)
+	t.Run("gitlab service - application token replaces default token", gitlabServiceApplicationToken)
+	t.Run("gitlab service - error status", gitlabServiceErrorStatus)
+}
+
+func gitlabServiceNestedGroupProjectID(t *testing.T) {
+	var escapedPath string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		escapedPath = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`{"commit": {"id": "0123456789abcdef"}}`))
+	})
+
+	service := NewGitlabService(server.URL+"/", "")
+
+	commitSha, err := service.GetBranchHead(context.TODO(), "test-group/test-subgroup", "test-repo", "feature/login", "")
+	require.NoError(t, err)
+	require.Equal(t, "0123456789abcdef", commitSha)
+	require.Equal(t, "/api/v4/projects/test-group%2Ftest-subgroup%2Ftest-repo/repository/branches/feature%2Flogin", escapedPath)
+}
+
+func gitlabServiceGetFilesMetadata(t *testing.T) {
+	var method, escapedPath, ref string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		escapedPath = r.URL.EscapedPath()
+		ref = r.URL.Query().Get("ref")
+		w.Header().Set(gitlabBlobIDHeader, "blob-sha")
+		w.Header().Set(gitlabFileNameHeader, "openclient.json")
+		w.Header().Set(gitlabFilePathHeader, "docs/openclient.json")
+		w.Header().Set(gitlabSizeHeader, "42")
+	})
+
+	service := NewGitlabService(server.URL, "")
+
+	filesMetadata, err := service.GetFilesMetadata(context.TODO(), "test-owner", "test-repo", "main", []string{"/docs/openclient.json"}, "")
+	require.NoError(t, err)
+	require.Equal(t, http.MethodHead, method)
+	require.Equal(t, "/api/v4/projects/test-owner%2Ftest-repo/repository/files/docs%2Fopenclient.json", escapedPath)
+	require.Equal(t, "main", ref)
+	require.Equal(t, &model.FileMetadata{
+		Name:       "openclient.json",
+		Path:       "docs/openclient.json",
+		Size:       42,
+		SHA:        "blob-sha",
+		Branch:     "main",
+		Repository: "test-repo",
+		Owner:      "test-owner",
+	}, filesMetadata["/docs/openclient.json"])
+}
+
+func gitlabServiceGetFilesMetadataNotFound(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() == "/api/v4/projects/test-owner%2Ftest-repo/repository/files/docs%2Fopenapi.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(gitlabBlobIDHeader, "blob-sha")
+	})
+
+	service := NewGitlabService(server.URL, "")
+
+	filesMetadata, err := service.GetFilesMetadata(context.TODO(), "test-owner", "test-repo", "main", []string{"docs/openclient.json", "docs/openapi.json"}, "")
+	require.NoError(t, err)
+	require.Len(t, filesMetadata, 1)
+	require.Equal(t, "blob-sha", filesMetadata["docs/openclient.json"].SHA)
+}
+
+func gitlabServiceGetFileWithContent(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"file_name": "openclient.json", "file_path": "docs/openclient.json", "size": %d, "encoding": "base64", "content": "%s", "blob_id": "blob-sha"}`,
+			len(mockedOpenClientContent), base64.StdEncoding.EncodeToString([]byte(mockedOpenClientContent)))
+	})
+
+	service := NewGitlabService(server.URL, "")
+
+	fileContent, err := service.GetFileWithContent(context.TODO(), "test-owner", "test-repo", "main", "docs/openclient.json", "")
+	require.NoError(t, err)
+	require.Equal(t, mockedOpenClientContent, fileContent.Content)
+	require.Equal(t, "blob-sha", fileContent.Metadata.SHA)
+	require.Equal(t, "docs/openclient.json", fileContent.Metadata.Path)
+	require.Equal(t, len(mockedOpenClientContent), fileContent.Metadata.Size)
+}
+
+func gitlabServiceGetFileWithContentNotFound(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	service := NewGitlabService(server.URL, "")
+
+	_, err := service.GetFileWithContent(context.TODO(), "test-owner", "test-repo", "main", "docs/openclient.json", "")
+	require.Error(t, err)
+
+	var programErr errors.ProgramError
+	require.True(t, errorUtils.As(err, &programErr))
+	require.Equal(t, errors.NotFound, programErr.Type())
+}
+
+func gitlabServiceDefaultToken(t *testing.T) {
+	var token string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get(gitlabTokenHeader)
+		_, _ = w.Write([]byte(`{"commit": {"id": "0123456789abcdef"}}`))
+	})
+
+	service := NewGitlabService(server.URL, "default-token")
+
+	_, err := service.GetBranchHead(context.TODO(), "test-owner", "test-repo", "main", "")
+	require.NoError(t, err)
+	require.Equal(t, "default-token", token)
+}
+
+func gitlabServiceApplicationToken(t *testing.T) {
+	var token string
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get(gitlabTokenHeader)
+		_, _ = w.Write([]byte(`{"commit": {"id": "0123456789abcdef"}}`))
+	})
+
+	service := NewGitlabService(server.URL, "default-token")
+
+	_, err := service.GetBranchHead(context.TODO(), "test-owner", "test-repo", "main", "application-token")
+	require.NoError(t, err)
+	require.Equal(t, "application-token", token)
+}
+
+func gitlabServiceErrorStatus(t *testing.T) {
+	server := newGitTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	service := NewGitlabService(server.URL, "")
+
+	_, err := service.GetCommit(context.TODO(), "test-owner", "test-repo", "0123456789abcdef", "")
+	require.Error(t, err)
+
+	var statusErr *model.GitStatusError
+	require.True(t, errorUtils.As(err, &statusErr))
+	require.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+}
@@ -0,0 +1,222 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/model"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// localGitService reads files from bare or working git repositories stored under a root directory, laid out as
+// <root>/<owner>/<repo> or <root>/<owner>/<repo>.git. The SHA of a file is its git blob SHA, as with GitHub.
+type localGitService struct {
+	rootPath string
+}
+
+type localGitEntry struct {
+	sha  string
+	size int
+}
+
+func NewLocalGitService(rootPath string) GitService {
+	return &localGitService{rootPath: rootPath}
+}
+
+func (l *localGitService) GetBranchHead(ctx context.Context, owner, repo, branch, _ string) (string, error) {
+	repositoryPath, err := l.getRepositoryPath(owner, repo)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(branch, "-") {
+		return "", fmt.Errorf("invalid branch name %s", branch)
+	}
+
+	output, err := runGit(ctx, repositoryPath, "rev-parse", "--verify", branch+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (l *localGitService) GetFilesMetadata(ctx context.Context, owner, repo, ref string, paths []string, _ string) (map[string]*model.FileMetadata, error) {
+	repositoryPath, err := l.getRepositoryPath(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := l.getEntries(ctx, repositoryPath, ref, paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	filesMetadata := make(map[string]*model.FileMetadata, len(entries))
+	for filePath, entry := range entries {
+		filesMetadata[filePath] = &model.FileMetadata{
+			Name:       path.Base(filePath),
+			Path:       filePath,
+			Size:       entry.size,
+			SHA:        entry.sha,
+			Branch:     ref,
+			Repository: repo,
+			Owner:      owner,
+		}
+	}
+
+	return filesMetadata, nil
+}
+
+func (l *localGitService) GetFileWithContent(ctx context.Context, owner, repo, ref, filePath, _ string) (*model.FileContent, error) {
+	repositoryPath, err := l.getRepositoryPath(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := l.getEntries(ctx, repositoryPath, ref, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := entries[filePath]
+	if !ok {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("file %s not found in %s at %s", filePath, repositoryPath, ref))
+	}
+
+	content, err := runGit(ctx, repositoryPath, "cat-file", "blob", entry.sha)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := model.FileMetadata{
+		Name:       path.Base(filePath),
+		Path:       filePath,
+		Size:       entry.size,
+		SHA:        entry.sha,
+		Branch:     ref,
+		Repository: repo,
+		Owner:      owner,
+	}
+
+	return &model.FileContent{Metadata: metadata, Content: string(content)}, nil
+}
+
+func (l *localGitService) GetCommit(ctx context.Context, owner, repo, sha, _ string) (*model.GitCommit, error) {
+	repositoryPath, err := l.getRepositoryPath(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(sha, "-") {
+		return nil, fmt.Errorf("invalid commit %s", sha)
+	}
+
+	output, err := runGit(ctx, repositoryPath, "show", "-s", "--format=%H%x00%an%x00%ae%x00%aI%x00%B", sha+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.SplitN(string(output), "\x00", 5)
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("failed to parse commit %s", sha)
+	}
+
+	commit := &model.GitCommit{
+		Sha:         fields[0],
+		AuthorName:  fields[1],
+		AuthorEmail: fields[2],
+		Message:     strings.TrimSpace(fields[4]),
+	}
+	if date, err := time.Parse(time.RFC3339, fields[3]); err == nil {
+		commit.Date = &date
+	}
+
+	return commit, nil
+}
+
+func (l *localGitService) getRepositoryPath(owner, repo string) (string, error) {
+	if l.rootPath == "" {
+		return "", fmt.Errorf("local repositories path is not configured")
+	}
+
+	repositoryPath := filepath.Join(l.rootPath, owner, repo)
+	relativePath, err := filepath.Rel(l.rootPath, repositoryPath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("repository %s/%s is outside of the local repositories path", owner, repo)
+	}
+
+	for _, candidate := range []string{repositoryPath, repositoryPath + ".git"} {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	return "", errors.NewNotFoundError(fmt.Sprintf("repository %s/%s not found in %s", owner, repo, l.rootPath))
+}
+
+// getEntries returns the blobs found at ref keyed by the requested path, paths that do not exist are left out
+func (l *localGitService) getEntries(ctx context.Context, repositoryPath, ref string, paths ...string) (map[string]*localGitEntry, error) {
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid ref %s", ref)
+	}
+
+	requestedPaths := make(map[string]string, len(paths))
+	args := []string{"ls-tree", "-l", "-z", ref, "--"}
+	for _, filePath := range paths {
+		relativePath := strings.TrimPrefix(filePath, "/")
+		requestedPaths[relativePath] = filePath
+		args = append(args, relativePath)
+	}
+
+	output, err := runGit(ctx, repositoryPath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*localGitEntry, len(paths))
+
+	// Each entry has the format "<mode> <type> <sha> <size>\t<path>" and is terminated by a NUL byte
+	for _, line := range strings.Split(string(output), "\x00") {
+		info, entryPath, found := strings.Cut(line, "\t")
+		fields := strings.Fields(info)
+		if !found || len(fields) < 4 || fields[1] != "blob" {
+			continue
+		}
+
+		requestedPath, ok := requestedPaths[entryPath]
+		if !ok {
+			continue
+		}
+
+		size, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of file %s: %v", entryPath, err)
+		}
+
+		entries[requestedPath] = &localGitEntry{sha: fields[2], size: size}
+	}
+
+	return entries, nil
+}
+
+func runGit(ctx context.Context, repositoryPath string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repositoryPath}, args...)...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
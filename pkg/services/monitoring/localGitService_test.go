@@ -0,0 +1,110 @@
+package monitoring
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const mockedOpenClientContent = `{"name": "test-application"}`
+
+func TestLocalGitService(t *testing.T) {
+	t.Run("local git service - get branch head", localGitServiceGetBranchHead)
+	t.Run("local git service - get files metadata", localGitServiceGetFilesMetadata)
+	t.Run("local git service - get file with content", localGitServiceGetFileWithContent)
+	t.Run("local git service - get commit", localGitServiceGetCommit)
+	t.Run("local git service - file not found", localGitServiceFileNotFound)
+	t.Run("local git service - repository outside root", localGitServiceRepositoryOutsideRoot)
+}
+
+func setUpLocalRepository(t *testing.T) (GitService, string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	rootPath := t.TempDir()
+	workingPath := t.TempDir()
+
+	runTestGit(t, workingPath, "init", "--initial-branch=main")
+	require.NoError(t, os.MkdirAll(filepath.Join(workingPath, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingPath, "docs", "openclient.json"), []byte(mockedOpenClientContent), 0o644))
+	runTestGit(t, workingPath, "add", ".")
+	runTestGit(t, workingPath, "-c", "user.name=test", "-c", "user.email=test@test.com", "commit", "-m", "initial commit")
+	runTestGit(t, rootPath, "clone", "--bare", workingPath, filepath.Join(rootPath, "test-owner", "test-repo.git"))
+
+	blobSha := strings.TrimSpace(runTestGit(t, workingPath, "rev-parse", "main:docs/openclient.json"))
+
+	return NewLocalGitService(rootPath), blobSha
+}
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return string(output)
+}
+
+func localGitServiceGetBranchHead(t *testing.T) {
+	service, _ := setUpLocalRepository(t)
+
+	commitSha, err := service.GetBranchHead(context.TODO(), "test-owner", "test-repo", "main", "")
+	require.NoError(t, err)
+	require.Len(t, commitSha, 40)
+}
+
+func localGitServiceGetFilesMetadata(t *testing.T) {
+	service, blobSha := setUpLocalRepository(t)
+
+	filesMetadata, err := service.GetFilesMetadata(context.TODO(), "test-owner", "test-repo", "main", []string{"docs/openclient.json", "docs/openapi.json"}, "")
+	require.NoError(t, err)
+	require.Len(t, filesMetadata, 1)
+
+	metadata := filesMetadata["docs/openclient.json"]
+	require.Equal(t, blobSha, metadata.SHA)
+	require.Equal(t, len(mockedOpenClientContent), metadata.Size)
+	require.Equal(t, "openclient.json", metadata.Name)
+}
+
+func localGitServiceGetFileWithContent(t *testing.T) {
+	service, blobSha := setUpLocalRepository(t)
+
+	file, err := service.GetFileWithContent(context.TODO(), "test-owner", "test-repo", "main", "docs/openclient.json", "")
+	require.NoError(t, err)
+	require.Equal(t, blobSha, file.Metadata.SHA)
+	require.Equal(t, mockedOpenClientContent, file.Content)
+}
+
+func localGitServiceGetCommit(t *testing.T) {
+	service, _ := setUpLocalRepository(t)
+
+	commitSha, err := service.GetBranchHead(context.TODO(), "test-owner", "test-repo", "main", "")
+	require.NoError(t, err)
+
+	commit, err := service.GetCommit(context.TODO(), "test-owner", "test-repo", commitSha, "")
+	require.NoError(t, err)
+	require.Equal(t, commitSha, commit.Sha)
+	require.Equal(t, "initial commit", commit.Message)
+	require.Equal(t, "test", commit.AuthorName)
+	require.Equal(t, "test@test.com", commit.AuthorEmail)
+	require.NotNil(t, commit.Date)
+}
+
+func localGitServiceFileNotFound(t *testing.T) {
+	service, _ := setUpLocalRepository(t)
+
+	_, err := service.GetFileWithContent(context.TODO(), "test-owner", "test-repo", "main", "docs/openapi.json", "")
+	require.Error(t, err)
+}
+
+func localGitServiceRepositoryOutsideRoot(t *testing.T) {
+	service, _ := setUpLocalRepository(t)
+
+	_, err := service.GetBranchHead(context.TODO(), "..", "test-repo", "main", "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "outside of the local repositories path")
+}
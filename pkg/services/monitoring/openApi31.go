@@ -0,0 +1,261 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// convertedOpenAPIVersion is the version OpenAPI 3.1 documents are converted to, kin-openapi only models OpenAPI 3.0
+const convertedOpenAPIVersion = "3.0.3"
+
+// schemaMapKeywords hold a map of schemas, schemaListKeywords a list of schemas and schemaKeywords a single schema
+var (
+	schemaMapKeywords  = []string{"properties", "patternProperties", "dependentSchemas", "$defs", "definitions"}
+	schemaListKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+	schemaKeywords     = []string{"not", "contains", "propertyNames", "if", "then", "else", "unevaluatedItems", "unevaluatedProperties"}
+)
+
+// loadOpenAPI31Spec converts an OpenAPI 3.1 document to OpenAPI 3.0 before loading it. The schemas are written the way
+// an OpenAPI 3.0 document writes them, a type list with null becomes a nullable type, so that comparing a 3.0 and a 3.1
+// version of a specification only reports actual changes. Webhooks are kept under the x-webhooks extension.
+func (s *openApiService) loadOpenAPI31Spec(document map[string]interface{}) (*openapi3.T, error) {
+	document = convertOpenAPI31Node(toStringKeyedMaps(document)).(map[string]interface{})
+
+	document["openapi"] = convertedOpenAPIVersion
+	delete(document, "jsonSchemaDialect")
+
+	if webhooks, ok := document["webhooks"]; ok {
+		document["x-webhooks"] = webhooks
+		delete(document, "webhooks")
+	}
+
+	// Paths are optional in OpenAPI 3.1, a document may only describe webhooks or components
+	if _, ok := document["paths"]; !ok {
+		document["paths"] = map[string]interface{}{}
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI 3.1 spec to 3.0: %s", err.Error())
+	}
+
+	return openapi3.NewLoader().LoadFromData(data)
+}
+
+// toStringKeyedMaps gives string keys to the maps decoded from YAML with other keys, like the status codes of responses
+func toStringKeyedMaps(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = toStringKeyedMaps(child)
+		}
+		return value
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, child := range value {
+			converted[fmt.Sprint(key)] = toStringKeyedMaps(child)
+		}
+		return converted
+	case []interface{}:
+		for i, child := range value {
+			value[i] = toStringKeyedMaps(child)
+		}
+		return value
+	default:
+		return node
+	}
+}
+
+// convertOpenAPI31Node walks the document down to its schemas, examples are left untouched as they are not schemas
+func convertOpenAPI31Node(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			switch key {
+			case "schema":
+				value[key] = convertOpenAPI31Schema(child)
+			case "schemas":
+				value[key] = convertOpenAPI31SchemaMap(child)
+			case "example", "examples":
+			default:
+				value[key] = convertOpenAPI31Node(child)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, child := range value {
+			value[i] = convertOpenAPI31Node(child)
+		}
+		return value
+	default:
+		return node
+	}
+}
+
+func convertOpenAPI31SchemaMap(node interface{}) interface{} {
+	schemas, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	for name, schema := range schemas {
+		schemas[name] = convertOpenAPI31Schema(schema)
+	}
+
+	return schemas
+}
+
+func convertOpenAPI31Schema(node interface{}) interface{} {
+	if accepted, ok := node.(bool); ok {
+		if accepted {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"not": map[string]interface{}{}}
+	}
+
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	// The siblings of a reference are ignored in OpenAPI 3.0
+	if _, ok := schema["$ref"]; ok {
+		return schema
+	}
+
+	convertOpenAPI31Type(schema)
+	convertOpenAPI31NullableAlternatives(schema)
+	convertOpenAPI31ExclusiveBound(schema, "exclusiveMinimum", "minimum", func(exclusiveBound, bound float64) bool { return exclusiveBound >= bound })
+	convertOpenAPI31ExclusiveBound(schema, "exclusiveMaximum", "maximum", func(exclusiveBound, bound float64) bool { return exclusiveBound <= bound })
+
+	if constValue, ok := schema["const"]; ok {
+		if _, hasEnum := schema["enum"]; !hasEnum {
+			schema["enum"] = []interface{}{constValue}
+		}
+		delete(schema, "const")
+	}
+
+	if examples, ok := schema["examples"].([]interface{}); ok {
+		if _, hasExample := schema["example"]; !hasExample && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		delete(schema, "examples")
+	}
+
+	// items cannot be a boolean in OpenAPI 3.0, the items of a tuple are described by prefixItems
+	if _, ok := schema["items"].(bool); ok {
+		delete(schema, "items")
+	} else if items, ok := schema["items"]; ok {
+		schema["items"] = convertOpenAPI31Schema(items)
+	}
+
+	if additionalProperties, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+		schema["additionalProperties"] = convertOpenAPI31Schema(additionalProperties)
+	}
+
+	for _, keyword := range schemaMapKeywords {
+		if schemas, ok := schema[keyword]; ok {
+			schema[keyword] = convertOpenAPI31SchemaMap(schemas)
+		}
+	}
+
+	for _, keyword := range schemaListKeywords {
+		if schemas, ok := schema[keyword].([]interface{}); ok {
+			for i, child := range schemas {
+				schemas[i] = convertOpenAPI31Schema(child)
+			}
+		}
+	}
+
+	for _, keyword := range schemaKeywords {
+		if child, ok := schema[keyword]; ok {
+			schema[keyword] = convertOpenAPI31Schema(child)
+		}
+	}
+
+	return schema
+}
+
+// convertOpenAPI31Type turns the null type into the nullable keyword of OpenAPI 3.0
+func convertOpenAPI31Type(schema map[string]interface{}) {
+	switch schemaType := schema["type"].(type) {
+	case string:
+		if schemaType == "null" {
+			delete(schema, "type")
+			schema["nullable"] = true
+		}
+	case []interface{}:
+		types := make([]interface{}, 0, len(schemaType))
+		for _, t := range schemaType {
+			if t == "null" {
+				schema["nullable"] = true
+				continue
+			}
+			types = append(types, t)
+		}
+
+		switch len(types) {
+		case 0:
+			delete(schema, "type")
+		case 1:
+			schema["type"] = types[0]
+		default:
+			schema["type"] = types
+		}
+	}
+}
+
+// convertOpenAPI31NullableAlternatives turns a null alternative of anyOf or oneOf, like anyOf: [{$ref: ...}, {type: null}],
+// into the nullable keyword of OpenAPI 3.0
+func convertOpenAPI31NullableAlternatives(schema map[string]interface{}) {
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		alternatives, ok := schema[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+
+		remaining := make([]interface{}, 0, len(alternatives))
+		for _, alternative := range alternatives {
+			if alternativeSchema, ok := alternative.(map[string]interface{}); ok && len(alternativeSchema) == 1 && alternativeSchema["type"] == "null" {
+				schema["nullable"] = true
+				continue
+			}
+			remaining = append(remaining, alternative)
+		}
+		schema[keyword] = remaining
+	}
+}
+
+// convertOpenAPI31ExclusiveBound turns a numeric exclusive bound into the bound and boolean exclusive flag of OpenAPI
+// 3.0, keeping the bound when it is the most restrictive of the two
+func convertOpenAPI31ExclusiveBound(schema map[string]interface{}, exclusiveKeyword, keyword string, restricts func(exclusiveBound, bound float64) bool) {
+	exclusiveBound, ok := toFloat(schema[exclusiveKeyword])
+	if !ok {
+		return
+	}
+
+	if bound, ok := toFloat(schema[keyword]); ok && !restricts(exclusiveBound, bound) {
+		delete(schema, exclusiveKeyword)
+		return
+	}
+
+	schema[keyword] = schema[exclusiveKeyword]
+	schema[exclusiveKeyword] = true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	default:
+		return 0, false
+	}
+}
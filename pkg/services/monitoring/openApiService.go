@@ -15,7 +15,9 @@ import (
 )
 
 type OpenApiService interface {
-	ParseOpenApiSpec(specContent string) (*openapi3.T, error)
+	// ParseOpenApiSpec returns the specification with the OpenAPI version its document declares. Swagger 2 and OpenAPI
+	// 3.1 documents are converted to OpenAPI 3.0, the version specifications are stored and compared in
+	ParseOpenApiSpec(specContent string) (*openapi3.T, string, error)
 	CompareOpenApiSpecs(spec1, spec2 *openapi3.T) (checker.Changes, error)
 }
 
@@ -25,61 +27,77 @@ func NewOpenApiService() OpenApiService {
 	return &openApiService{}
 }
 
-func (s *openApiService) ParseOpenApiSpec(specContent string) (*openapi3.T, error) {
-	version, err := s.detectOpenAPIVersion(specContent)
+func (s *openApiService) ParseOpenApiSpec(specContent string) (*openapi3.T, string, error) {
+	document, err := s.decodeOpenAPIDocument(specContent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect OpenAPI version: %s", err.Error())
+		return nil, "", fmt.Errorf("failed to detect OpenAPI version: %s", err.Error())
+	}
+
+	version, err := s.detectOpenAPIVersion(document)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to detect OpenAPI version: %s", err.Error())
 	}
 
 	var doc *openapi3.T
 
-	if version == 3 {
+	switch {
+	case strings.HasPrefix(version, "3.0"):
 		loader := openapi3.NewLoader()
 		doc, err = loader.LoadFromData([]byte(specContent))
 		if err != nil {
-			return nil, fmt.Errorf("failed to load OpenAPI 3 spec: %s", err.Error())
+			return nil, "", fmt.Errorf("failed to load OpenAPI 3 spec: %s", err.Error())
 		}
-	} else if version == 2 {
+	case version == "3.1" || strings.HasPrefix(version, "3.1."):
+		doc, err = s.loadOpenAPI31Spec(document)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load OpenAPI %s spec: %s", version, err.Error())
+		}
+	case strings.HasPrefix(version, "2.0"):
 		var swagger2 openapi2.T
 		if err := swagger2.UnmarshalJSON([]byte(specContent)); err != nil {
 			// If not JSON, we try YAML
 			if err := yaml.Unmarshal([]byte(specContent), &swagger2); err != nil {
-				return nil, fmt.Errorf("spec is neither valid JSON nor YAML: %s", err.Error())
+				return nil, "", fmt.Errorf("spec is neither valid JSON nor YAML: %s", err.Error())
 			}
 		}
 
 		doc, err = openapi2conv.ToV3(&swagger2)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert OpenAPI 2 spec to 3: %s", err.Error())
+			return nil, "", fmt.Errorf("failed to convert OpenAPI 2 spec to 3: %s", err.Error())
 		}
-	} else {
-		return nil, fmt.Errorf("unsupported OpenAPI version: %d", version)
+	default:
+		return nil, "", fmt.Errorf("unsupported OpenAPI version: %s", version)
 	}
 
-	return doc, nil
+	return doc, version, nil
 }
 
-func (s *openApiService) detectOpenAPIVersion(spec string) (int, error) {
+func (s *openApiService) decodeOpenAPIDocument(spec string) (map[string]interface{}, error) {
 	// We try JSON first
 	var obj map[string]interface{}
 	if err := json.Unmarshal([]byte(spec), &obj); err != nil {
 		// If not JSON, we try YAML
 		if err := yaml.Unmarshal([]byte(spec), &obj); err != nil {
-			return 0, fmt.Errorf("spec is neither valid JSON nor YAML: %w", err)
+			return nil, fmt.Errorf("spec is neither valid JSON nor YAML: %w", err)
 		}
 	}
 
+	return obj, nil
+}
+
+// detectOpenAPIVersion returns the version the document declares, like 2.0 or 3.1.0
+func (s *openApiService) detectOpenAPIVersion(obj map[string]interface{}) (string, error) {
 	if v, ok := obj["swagger"]; ok {
 		if s, ok := v.(string); ok && strings.HasPrefix(s, "2.0") {
-			return 2, nil
+			return s, nil
 		}
 	}
 	if v, ok := obj["openapi"]; ok {
 		if s, ok := v.(string); ok && strings.HasPrefix(s, "3") {
-			return 3, nil
+			return s, nil
 		}
 	}
-	return 0, fmt.Errorf("could not detect OpenAPI version")
+	return "", fmt.Errorf("could not detect OpenAPI version")
 }
 
 func (s *openApiService) CompareOpenApiSpecs(spec1, spec2 *openapi3.T) (checker.Changes, error) {
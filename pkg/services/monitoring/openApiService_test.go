@@ -0,0 +1,220 @@
+package monitoring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const openAPI30UsersSpec = `{
+	"openapi": "3.0.3",
+	"info": {"title": "users", "version": "1.0.0"},
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"responses": {
+					"200": {
+						"description": "a user",
+						"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}
+					}
+				}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string"},
+					"nickname": {"type": "string", "nullable": true},
+					"age": {"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+					"kind": {"type": "string", "enum": ["user"]},
+					"tags": {"type": "array", "items": {"type": "string"}, "example": ["admin"]}
+				}
+			}
+		}
+	}
+}`
+
+const openAPI31UsersSpec = `{
+	"openapi": "3.1.0",
+	"jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+	"info": {"title": "users", "version": "1.0.0"},
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"responses": {
+					"200": {
+						"description": "a user",
+						"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}
+					}
+				}
+			}
+		}
+	},
+	"webhooks": {
+		"userCreated": {
+			"post": {
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+				"responses": {"200": {"description": "received"}}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string"},
+					"nickname": {"type": ["string", "null"]},
+					"age": {"type": "integer", "exclusiveMinimum": 0},
+					"kind": {"type": "string", "const": "user"},
+					"tags": {"type": "array", "items": {"type": "string"}, "examples": [["admin"]]}
+				}
+			}
+		}
+	}
+}`
+
+func TestParseOpenApiSpec(t *testing.T) {
+	t.Run("parse openapi spec - openapi 3.1 document is converted", parseOpenApiSpecOpenAPI31)
+	t.Run("parse openapi spec - openapi 3.1 yaml document is converted", parseOpenApiSpecOpenAPI31YAML)
+	t.Run("parse openapi spec - openapi 3.0 document is kept", parseOpenApiSpecOpenAPI30)
+	t.Run("parse openapi spec - unsupported version", parseOpenApiSpecUnsupportedVersion)
+	t.Run("parse openapi spec - unsupported openapi 3 version", parseOpenApiSpecUnsupportedOpenAPI3Version)
+}
+
+func TestCompareOpenApiSpecs(t *testing.T) {
+	t.Run("compare openapi specs - same specification in openapi 3.0 and 3.1", compareOpenApiSpecsOpenAPI30And31)
+	t.Run("compare openapi specs - change between openapi 3.0 and 3.1", compareOpenApiSpecsOpenAPI30And31Change)
+}
+
+func parseOpenApiSpecOpenAPI31(t *testing.T) {
+	service := NewOpenApiService()
+
+	spec, version, err := service.ParseOpenApiSpec(openAPI31UsersSpec)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", version)
+	require.Equal(t, convertedOpenAPIVersion, spec.OpenAPI)
+
+	user := spec.Components.Schemas["User"].Value
+	require.True(t, user.Properties["nickname"].Value.Type.Is("string"))
+	require.True(t, user.Properties["nickname"].Value.Nullable)
+	require.Equal(t, float64(0), *user.Properties["age"].Value.Min)
+	require.True(t, user.Properties["age"].Value.ExclusiveMin)
+	require.Equal(t, []any{"user"}, user.Properties["kind"].Value.Enum)
+	require.Equal(t, []any{"admin"}, user.Properties["tags"].Value.Example)
+
+	require.Contains(t, spec.Extensions, "x-webhooks")
+	require.NotContains(t, spec.Extensions, "webhooks")
+	require.NotContains(t, spec.Extensions, "jsonSchemaDialect")
+
+	translator := NewTranslator()
+	openApiObj, err := translator.ToApplicationOpenApiObj(spec, version)
+	require.NoError(t, err)
+	storedSpec, err := translator.ToApplicationOpenApiModel(openApiObj)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", storedSpec.OpenAPIVersion)
+	require.Contains(t, storedSpec.OpenAPISpec.Extensions, "x-webhooks")
+	require.True(t, storedSpec.OpenAPISpec.Components.Schemas["User"].Value.Properties["nickname"].Value.Nullable)
+}
+
+func parseOpenApiSpecOpenAPI31YAML(t *testing.T) {
+	service := NewOpenApiService()
+
+	spec, version, err := service.ParseOpenApiSpec(`
+openapi: 3.1.1
+info:
+  title: orders
+  version: 1.0.0
+webhooks:
+  orderShipped:
+    post:
+      responses:
+        200:
+          description: received
+components:
+  schemas:
+    Order:
+      type: [object, "null"]
+      properties:
+        total:
+          type: number
+          exclusiveMaximum: 1000
+`)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.1", version)
+	require.NotNil(t, spec.Paths)
+	require.Zero(t, spec.Paths.Len())
+
+	order := spec.Components.Schemas["Order"].Value
+	require.True(t, order.Type.Is("object"))
+	require.True(t, order.Nullable)
+	require.Equal(t, float64(1000), *order.Properties["total"].Value.Max)
+	require.True(t, order.Properties["total"].Value.ExclusiveMax)
+}
+
+func parseOpenApiSpecOpenAPI30(t *testing.T) {
+	service := NewOpenApiService()
+
+	spec, version, err := service.ParseOpenApiSpec(openAPI30UsersSpec)
+	require.NoError(t, err)
+	require.Equal(t, "3.0.3", version)
+	require.Equal(t, "3.0.3", spec.OpenAPI)
+	require.True(t, spec.Components.Schemas["User"].Value.Properties["nickname"].Value.Nullable)
+}
+
+func parseOpenApiSpecUnsupportedVersion(t *testing.T) {
+	service := NewOpenApiService()
+
+	_, _, err := service.ParseOpenApiSpec(`{"swagger": "1.2", "info": {"title": "legacy", "version": "1.0.0"}}`)
+	require.Error(t, err)
+}
+
+func parseOpenApiSpecUnsupportedOpenAPI3Version(t *testing.T) {
+	service := NewOpenApiService()
+
+	for _, version := range []string{"3.2.0", "3.10.0", "3foo"} {
+		_, _, err := service.ParseOpenApiSpec(`{"openapi": "` + version + `", "info": {"title": "users", "version": "1.0.0"}, "paths": {}}`)
+		require.Error(t, err)
+		require.Equal(t, "unsupported OpenAPI version: "+version, err.Error())
+	}
+}
+
+func compareOpenApiSpecsOpenAPI30And31(t *testing.T) {
+	service := NewOpenApiService()
+
+	openAPI30Spec, _, err := service.ParseOpenApiSpec(openAPI30UsersSpec)
+	require.NoError(t, err)
+	openAPI31Spec, _, err := service.ParseOpenApiSpec(openAPI31UsersSpec)
+	require.NoError(t, err)
+
+	changes, err := service.CompareOpenApiSpecs(openAPI30Spec, openAPI31Spec)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	changes, err = service.CompareOpenApiSpecs(openAPI31Spec, openAPI30Spec)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func compareOpenApiSpecsOpenAPI30And31Change(t *testing.T) {
+	service := NewOpenApiService()
+
+	openAPI30Spec, _, err := service.ParseOpenApiSpec(openAPI30UsersSpec)
+	require.NoError(t, err)
+	// id is no longer required
+	openAPI31Spec, _, err := service.ParseOpenApiSpec(strings.Replace(openAPI31UsersSpec, `"required": ["id"],`, "", 1))
+	require.NoError(t, err)
+
+	changes, err := service.CompareOpenApiSpecs(openAPI30Spec, openAPI31Spec)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "response-property-became-optional", changes[0].GetId())
+}
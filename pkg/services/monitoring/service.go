@@ -12,11 +12,27 @@ import (
 	"encoding/json"
 	errorUtils "errors"
 	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
+	"github.com/oasdiff/oasdiff/formatters"
 )
 
 const (
-	SentinelSettingsName = "sentinel_settings"
+	SentinelSettingsName    = "sentinel_settings"
+	SentinelLeaderLeaseName = "sentinel_leader"
+	// MaxSyncJobAttempts bounds how often a sync job is claimed again after being interrupted or rate limited
+	MaxSyncJobAttempts         = 3
+	MaxSentinelRunsPageSize    = 100
+	MaxOpenAPIVersionsPageSize = 100
+	// openAPIDifferencesNotificationTimeout bounds the notification of the differences, which outlives the sync
+	openAPIDifferencesNotificationTimeout = 5 * time.Minute
 )
 
 //go:generate mockgen -destination=./mock/service_mock.go -package=mock cosmos-server/pkg/services/monitoring Service
@@ -27,131 +43,306 @@ type Service interface {
 	GetApplicationsInteractions(ctx context.Context, filter model.ApplicationDependencyFilter) (*model.ApplicationsInteractions, error)
 	UpdateApplicationOpenAPISpecification(ctx context.Context, application *model.Application) error
 	GetApplicationOpenAPISpecification(ctx context.Context, application *model.Application) (*model.ApplicationOpenAPISpecification, error)
+	// GetApplicationOpenAPISpecificationVersions lists the versions ingested up to at, the first one being the specification the application had at that time
+	GetApplicationOpenAPISpecificationVersions(ctx context.Context, application *model.Application, at *time.Time, page, pageSize int) (*model.ApplicationOpenAPIVersionsPage, error)
+	GetApplicationOpenAPISpecificationVersion(ctx context.Context, application *model.Application, versionID uint) (*model.ApplicationOpenAPIVersion, error)
+	GetApplicationOpenAPIRetention(ctx context.Context, application *model.Application) (*model.OpenAPIRetention, error)
+	UpdateApplicationOpenAPIRetention(ctx context.Context, application *model.Application, retention *model.OpenAPIRetention) error
+	// CompareApplicationOpenAPISpecifications diffs two specifications of the application, rendering the changes too when format is set
+	CompareApplicationOpenAPISpecifications(ctx context.Context, application *model.Application, base, revision *model.OpenAPISpecificationReference, format string) (*model.OpenAPIDiff, error)
+	// CheckApplicationOpenAPISpecification compares a candidate specification with the stored one, keeping only the changes consumers are affected by
+	CheckApplicationOpenAPISpecification(ctx context.Context, application *model.Application, candidateSpec string, failOn string) (*model.OpenAPICheck, error)
+	// ValidateApplicationOpenClientSpecification checks a candidate openclient.json of the application against the registered providers and their stored specifications
+	ValidateApplicationOpenClientSpecification(ctx context.Context, application *model.Application, candidateSpec string) (*model.OpenClientValidation, error)
+	PreviewApplicationSync(ctx context.Context, application *model.Application, changes *model.ApplicationUpdate) (*model.SyncPreview, error)
+	// GetApplicationContractDrifts lists the endpoints the application declares as a consumer, or is expected to serve as a provider, that are missing from the provider specification
+	GetApplicationContractDrifts(ctx context.Context, application *model.Application) ([]*model.ContractDrift, error)
+	GetContractDrifts(ctx context.Context) ([]*model.ContractDrift, error)
+	// GetProviderOperations lists the operations consumers declare on the provider, with who calls them and why
+	GetProviderOperations(ctx context.Context, provider *model.Application, filter model.EndpointConsumerFilter) ([]*model.ProviderOperation, error)
+	// GetApplicationUnusedOperations lists the operations of the stored specification of the application no registered consumer declares
+	GetApplicationUnusedOperations(ctx context.Context, application *model.Application) (*model.UnusedOperationsReport, error)
+	GetApplicationsUnusedOperations(ctx context.Context, applications []*model.Application) ([]*model.UnusedOperationsReport, error)
 
 	GetGroupApplicationsInteractions(ctx context.Context, groupName string) (*model.ApplicationsInteractions, error)
 
 	SentinelSettingsPresent(ctx context.Context) (bool, error)
 	InsertSentinelIntervalSetting(ctx context.Context, interval int, enabled bool) error
 	StoreSentinelChannel(newConfigChannel chan<- model.SentinelSettings)
+	StoreSentinelWorkersProvider(workersProvider func() []*model.SentinelWorker)
+	GetSentinelWorkers(ctx context.Context) (*model.SentinelWorkers, error)
+	EnqueueApplicationsSync(ctx context.Context, trigger string, applications []*model.Application) ([]*model.SyncJob, error)
+	GetSyncJob(ctx context.Context, jobID uint) (*model.SyncJob, error)
+	ClaimSyncJob(ctx context.Context, holder string) (*model.SyncJob, error)
+	CompleteSyncJob(ctx context.Context, job *model.SyncJob, result *model.ApplicationSyncResult) error
+	RequeueSyncJob(ctx context.Context, job *model.SyncJob, availableAt time.Time, reason string) error
+	RequeueStaleSyncJobs(ctx context.Context, startedBefore time.Time) error
+	GetActiveSyncJobs(ctx context.Context) ([]*model.SyncJob, error)
 
 	UpdateSentinelSettings(ctx context.Context, sentinelSettingsUpdate *model.SentinelSettingsUpdate) error
 	GetSentinelSettings(ctx context.Context) (*model.SentinelSettings, error)
+	AcquireSentinelLeadership(ctx context.Context, holder string, leaseDuration time.Duration) (bool, error)
+	ReleaseSentinelLeadership(ctx context.Context, holder string) error
+
+	SyncApplication(ctx context.Context, application *model.Application) *model.ApplicationSyncResult
+	StartSentinelRun(ctx context.Context, trigger string) (*model.SentinelRun, error)
+	RecordSentinelRunApplication(ctx context.Context, runID uint, result *model.ApplicationSyncResult) error
+	FinishSentinelRun(ctx context.Context, runID uint) error
+	GetSentinelRuns(ctx context.Context, page, pageSize int) (*model.SentinelRunsPage, error)
+	GetSentinelRun(ctx context.Context, runID uint) (*model.SentinelRun, error)
+
+	GetGitRateLimits(ctx context.Context) []*model.GitRateLimit
+	ReleaseApplicationQuarantine(ctx context.Context, applicationName string) error
 }
 
 type monitoringService struct {
 	storageService             storage.Service
-	gitService                 GitService
+	gitServices                map[string]GitService
 	encryptor                  token.Encryptor
 	openApiService             OpenApiService
 	mailService                mail.Service
 	sentinelConfigChannel      chan<- model.SentinelSettings
+	sentinelWorkersProvider    func() []*model.SentinelWorker
 	sentinelMaxIntervalSeconds int
 	sentinelMinIntervalSeconds int
+	syncPolicy                 SyncPolicy
 	translator                 Translator
 	logger                     log.Logger
 }
 
-func NewMonitoringService(storageService storage.Service, gitService GitService, openApiService OpenApiService, mailService mail.Service, sentinelMaxIntervalSeconds, sentinelMinIntervalSeconds int, encryptor token.Encryptor, translator Translator, logger log.Logger) Service {
+// SyncPolicy defines how often a sync failing with a transient error is retried within a run, and after how many
+// consecutive permanent failures an application is quarantined from automatic syncs
+type SyncPolicy struct {
+	MaxAttempts         int
+	BaseDelay           time.Duration
+	QuarantineThreshold int
+}
+
+func NewMonitoringService(storageService storage.Service, gitServices map[string]GitService, openApiService OpenApiService, mailService mail.Service, sentinelMaxIntervalSeconds, sentinelMinIntervalSeconds int, syncPolicy SyncPolicy, encryptor token.Encryptor, translator Translator, logger log.Logger) Service {
 	return &monitoringService{
 		storageService:             storageService,
-		gitService:                 gitService,
+		gitServices:                gitServices,
 		encryptor:                  encryptor,
 		openApiService:             openApiService,
 		mailService:                mailService,
 		sentinelMaxIntervalSeconds: sentinelMaxIntervalSeconds,
 		sentinelMinIntervalSeconds: sentinelMinIntervalSeconds,
+		syncPolicy:                 syncPolicy,
 		translator:                 translator,
 		logger:                     logger,
 	}
 }
 
+// repositorySnapshot holds the metadata of the monitored files at the head commit of the application branch, so
+// the branch is resolved and the tree is listed only once per sync
+type repositorySnapshot struct {
+	gitService GitService
+	token      string
+	commitSha  string
+	files      map[string]*model.FileMetadata
+}
+
 func (s *monitoringService) UpdateApplicationDependencies(ctx context.Context, application *model.Application) error {
+	if !s.shouldUpdateDependencies(application) {
+		return nil
+	}
+
+	snapshot, err := s.getRepositorySnapshot(ctx, application, application.MonitoringInformation.OpenClientPath)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.updateApplicationDependencies(ctx, application, snapshot)
+	return err
+}
+
+func (s *monitoringService) shouldUpdateDependencies(application *model.Application) bool {
 	if application.GitInformation == nil {
 		s.logger.Infof("No git information for application %s, skipping monitoring update", application.Name)
-		return nil // Could be an error because there is nothing to update.
+		return false // Could be an error because there is nothing to update.
 	}
 
 	if application.MonitoringInformation == nil {
 		s.logger.Infof("No monitoring information for application %s, skipping monitoring update", application.Name)
-		return nil
+		return false
 	}
 
 	if !application.MonitoringInformation.HasOpenClient {
 		s.logger.Infof("Application %s does not have OpenClient enabled, skipping monitoring update", application.Name)
-		return nil
+		return false
 	}
 
-	var applicationToken string
-	if application.Token != nil {
-		encryptedToken := application.Token.EncryptedValue
-		decryptedToken, err := s.encryptor.Decrypt(encryptedToken)
-		if err != nil {
-			return fmt.Errorf("failed to decrypt token for application %s: %v", application.Name, err)
-		}
-		applicationToken = decryptedToken
+	return true
+}
+
+func (s *monitoringService) getRepositorySnapshot(ctx context.Context, application *model.Application, paths ...string) (*repositorySnapshot, error) {
+	gitService, err := s.getGitService(application)
+	if err != nil {
+		return nil, model.NewSyncError(model.SyncErrorCategoryValidation, err)
+	}
+
+	applicationToken, err := s.getApplicationToken(application)
+	if err != nil {
+		return nil, model.NewSyncError(model.SyncErrorCategoryGitFetch, err)
+	}
+
+	gitInformation := application.GitInformation
+
+	commitSha, err := gitService.GetBranchHead(ctx, gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, applicationToken)
+	if err != nil {
+		return nil, newGitFetchError(fmt.Errorf("failed to get head of branch %s for application %s: %w", gitInformation.RepositoryBranch, application.Name, err))
+	}
+
+	files, err := gitService.GetFilesMetadata(ctx, gitInformation.RepositoryOwner, gitInformation.RepositoryName, commitSha, paths, applicationToken)
+	if err != nil {
+		return nil, newGitFetchError(fmt.Errorf("failed to get files metadata for application %s: %w", application.Name, err))
+	}
+
+	return &repositorySnapshot{
+		gitService: gitService,
+		token:      applicationToken,
+		commitSha:  commitSha,
+		files:      files,
+	}, nil
+}
+
+func (s *monitoringService) getApplicationToken(application *model.Application) (string, error) {
+	if application.Token == nil {
+		return "", nil
+	}
+
+	decryptedToken, err := s.encryptor.Decrypt(application.Token.EncryptedValue)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token for application %s: %v", application.Name, err)
+	}
+
+	return decryptedToken, nil
+}
+
+// newGitFetchError keeps rate limits apart from the other git errors, they are not a problem of the application
+func newGitFetchError(err error) error {
+	if isRateLimitError(err) {
+		return model.NewSyncError(model.SyncErrorCategoryRateLimit, err)
 	}
 
-	openClientMetadata, err := s.gitService.GetFileMetadata(ctx, application.GitInformation.RepositoryOwner, application.GitInformation.RepositoryName, application.GitInformation.RepositoryBranch, application.MonitoringInformation.OpenClientPath, applicationToken)
+	return model.NewSyncError(model.SyncErrorCategoryGitFetch, err)
+}
+
+// dependenciesChanges is what syncing the openclient.json file of an application writes to the dependency graph
+type dependenciesChanges struct {
+	dependenciesToUpsert map[string]*model.ApplicationDependency
+	pendingDependencies  map[string]*model.PendingApplicationDependency
+	dependenciesToDelete []*obj.ApplicationDependency
+}
+
+func (s *monitoringService) updateApplicationDependencies(ctx context.Context, application *model.Application, snapshot *repositorySnapshot) (string, error) {
+	openClientMetadata, err := s.getSnapshotFileMetadata(application, snapshot, application.MonitoringInformation.OpenClientPath)
 	if err != nil {
-		return fmt.Errorf("failed to get open clientmetadata for application %s: %v", application.Name, err)
+		return "", err
 	}
 
-	if application.MonitoringInformation != nil && application.MonitoringInformation.DependenciesSha == openClientMetadata.SHA {
+	if application.MonitoringInformation.DependenciesSha == openClientMetadata.SHA {
 		s.logger.Infof("Dependencies for application %s are up to date, skipping update", application.Name)
-		return nil
+		return openClientMetadata.SHA, nil
+	}
+
+	changes, err := s.getDependenciesChanges(ctx, application, snapshot, openClientMetadata)
+	if err != nil {
+		return openClientMetadata.SHA, err
+	}
+
+	dependenciesToUpsert := make(map[string]*obj.ApplicationDependency, len(changes.dependenciesToUpsert))
+	for dependencyName, dependency := range changes.dependenciesToUpsert {
+		dependenciesToUpsert[dependencyName] = s.translator.ToApplicationDependencyObj(dependency)
+	}
+
+	pendingDependencies := make(map[string]*obj.PendingApplicationDependency, len(changes.pendingDependencies))
+	for dependencyName, pendingDependency := range changes.pendingDependencies {
+		pendingDependencies[dependencyName] = s.translator.ToPendingApplicationDependencyObj(pendingDependency)
+	}
+
+	err = s.storageService.UpdateApplicationDependencies(ctx, application.Name, dependenciesToUpsert, pendingDependencies, changes.dependenciesToDelete, openClientMetadata.SHA)
+	if err != nil {
+		return openClientMetadata.SHA, model.NewSyncError(model.SyncErrorCategoryStorage, fmt.Errorf("failed to update dependencies for application %s: %v", application.Name, err))
+	}
+
+	s.refreshContractDrifts(ctx, application, s.storageService.GetApplicationDependenciesByConsumer)
+
+	return openClientMetadata.SHA, nil
+}
+
+func (s *monitoringService) getSnapshotFileMetadata(application *model.Application, snapshot *repositorySnapshot, path string) (*model.FileMetadata, error) {
+	metadata, ok := snapshot.files[path]
+	if !ok {
+		return nil, model.NewSyncError(model.SyncErrorCategoryGitFetch, errors.NewNotFoundError(fmt.Sprintf("file %s not found for application %s at commit %s", path, application.Name, snapshot.commitSha)))
 	}
 
-	rawOpenClientDefinition, err := s.gitService.GetFileWithContent(ctx, application.GitInformation.RepositoryOwner, application.GitInformation.RepositoryName, application.GitInformation.RepositoryBranch, application.MonitoringInformation.OpenClientPath, applicationToken)
+	return metadata, nil
+}
+
+func (s *monitoringService) getDependenciesChanges(ctx context.Context, application *model.Application, snapshot *repositorySnapshot, openClientMetadata *model.FileMetadata) (*dependenciesChanges, error) {
+	rawOpenClientDefinition, err := snapshot.gitService.GetFileWithContent(ctx, application.GitInformation.RepositoryOwner, application.GitInformation.RepositoryName, snapshot.commitSha, application.MonitoringInformation.OpenClientPath, snapshot.token)
 	if err != nil {
-		return fmt.Errorf("failed to get openclient.json for application %s: %v", application.Name, err)
+		return nil, newGitFetchError(fmt.Errorf("failed to get openclient.json for application %s: %w", application.Name, err))
 	}
 
 	if openClientMetadata.SHA != rawOpenClientDefinition.Metadata.SHA {
-		return fmt.Errorf("SHA mismatch for openclient.json of application %s", application.Name)
+		return nil, model.NewSyncError(model.SyncErrorCategoryGitFetch, fmt.Errorf("SHA mismatch for openclient.json of application %s", application.Name))
 	}
 
 	openClientDef, err := s.transformToOpenClientDefinition(rawOpenClientDefinition)
 	if err != nil {
-		return fmt.Errorf("failed to transform openclient.json for application %s: %v", application.Name, err)
+		return nil, model.NewSyncError(model.SyncErrorCategory(err), fmt.Errorf("failed to transform openclient.json for application %s: %v", application.Name, err))
 	}
 
 	dependenciesToUpsert, pendingDependencies, err := s.getDependenciesToModify(ctx, application, openClientDef)
 	if err != nil {
-		return fmt.Errorf("failed to get dependencies to modify for application %s: %v", application.Name, err)
+		return nil, model.NewSyncError(model.SyncErrorCategoryStorage, fmt.Errorf("failed to get dependencies to modify for application %s: %v", application.Name, err))
 	}
 
 	// We get the obsolete dependencies to delete them in batch
-	objDependenciesToDelete, err := s.getObsoleteDependencies(ctx, application, openClientDef)
+	dependenciesToDelete, err := s.getObsoleteDependencies(ctx, application, openClientDef)
 	if err != nil {
-		return fmt.Errorf("failed to get obsolete dependencies for application %s: %v", application.Name, err)
+		return nil, model.NewSyncError(model.SyncErrorCategoryStorage, fmt.Errorf("failed to get obsolete dependencies for application %s: %v", application.Name, err))
 	}
 
-	err = s.storageService.UpdateApplicationDependencies(ctx, application.Name, dependenciesToUpsert, pendingDependencies, objDependenciesToDelete, rawOpenClientDefinition.Metadata.SHA)
-	if err != nil {
-		return fmt.Errorf("failed to update dependencies for application %s: %v", application.Name, err)
+	return &dependenciesChanges{
+		dependenciesToUpsert: dependenciesToUpsert,
+		pendingDependencies:  pendingDependencies,
+		dependenciesToDelete: dependenciesToDelete,
+	}, nil
+}
+
+func (s *monitoringService) getGitService(application *model.Application) (GitService, error) {
+	provider := strings.ToLower(application.GitInformation.Provider)
+	if provider == "" {
+		provider = model.GitProviderGithub
 	}
 
-	return nil
+	gitService, ok := s.gitServices[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported git provider %s for application %s", application.GitInformation.Provider, application.Name)
+	}
+
+	return gitService, nil
 }
 
-func (s *monitoringService) getDependenciesToModify(ctx context.Context, application *model.Application, openClientDef *model.OpenClientSpecification) (map[string]*obj.ApplicationDependency, map[string]*obj.PendingApplicationDependency, error) {
-	dependenciesToUpsert := make(map[string]*obj.ApplicationDependency)
-	pendingDependencies := make(map[string]*obj.PendingApplicationDependency)
+func (s *monitoringService) getDependenciesToModify(ctx context.Context, application *model.Application, openClientDef *model.OpenClientSpecification) (map[string]*model.ApplicationDependency, map[string]*model.PendingApplicationDependency, error) {
+	dependenciesToUpsert := make(map[string]*model.ApplicationDependency)
+	pendingDependencies := make(map[string]*model.PendingApplicationDependency)
 
 	for dependencyName, dependency := range openClientDef.Dependencies {
 		dependencyObj, err := s.storageService.GetApplicationWithName(ctx, dependencyName)
 		if err != nil {
 			if errorUtils.Is(err, storage.ErrNotFound) {
 				s.logger.Warnf("Dependency application %s not found for application %s, skipping dependency creation", dependencyName, application.Name)
-				modelPendingDependency := s.transformToModelPendingDependency(application, dependencyName, dependency)
-				pendingDependencies[dependencyName] = s.translator.ToPendingApplicationDependencyObj(modelPendingDependency)
+				pendingDependencies[dependencyName] = s.transformToModelPendingDependency(application, dependencyName, dependency)
 				continue
 			}
 			return nil, nil, fmt.Errorf("failed to get dependency application %s for application %s: %v", dependencyName, application.Name, err)
 		}
 
-		modelDependency := s.transformToModelDependency(application, s.translator.ToApplicationModel(dependencyObj), dependency)
-		dependenciesToUpsert[dependencyName] = s.translator.ToApplicationDependencyObj(modelDependency)
+		dependenciesToUpsert[dependencyName] = s.transformToModelDependency(application, s.translator.ToApplicationModel(dependencyObj), dependency)
 	}
 
 	return dependenciesToUpsert, pendingDependencies, nil
@@ -162,11 +353,11 @@ func (s *monitoringService) transformToOpenClientDefinition(rawOpenClientDefinit
 	decoder := json.NewDecoder(strings.NewReader(rawOpenClientDefinition.Content))
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&openClientDef); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal openclient.json: %s", err.Error())
+		return nil, model.NewSyncError(model.SyncErrorCategoryParse, fmt.Errorf("failed to unmarshal openclient.json: %s", err.Error()))
 	}
 
 	if err := openClientDef.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid openclient.json :%s", err.Error())
+		return nil, model.NewSyncError(model.SyncErrorCategoryValidation, fmt.Errorf("invalid openclient.json :%s", err.Error()))
 	}
 
 	return &openClientDef, nil
@@ -251,93 +442,135 @@ func (s *monitoringService) GetApplicationsInteractions(ctx context.Context, fil
 }
 
 func (s *monitoringService) UpdateApplicationOpenAPISpecification(ctx context.Context, application *model.Application) error {
-	if application.GitInformation == nil {
-		s.logger.Infof("No git information for application %s, skipping OpenAPI spec update", application.Name)
-		return nil // Could be an error because there is nothing to update.
-	}
-
-	if !application.MonitoringInformation.HasOpenApi {
-		s.logger.Infof("Application %s does not have OpenAPI specification enabled, skipping OpenAPI spec update", application.Name)
+	if !s.shouldUpdateOpenAPISpecification(application) {
 		return nil
 	}
 
-	var applicationToken string
-	if application.Token != nil {
-		encryptedToken := application.Token.EncryptedValue
-		decryptedToken, err := s.encryptor.Decrypt(encryptedToken)
-		if err != nil {
-			return fmt.Errorf("failed to decrypt token for application %s: %v", application.Name, err)
-		}
-		applicationToken = decryptedToken
+	snapshot, err := s.getRepositorySnapshot(ctx, application, application.MonitoringInformation.OpenApiPath)
+	if err != nil {
+		return err
 	}
 
-	openApiSpecMetadata, err := s.gitService.GetFileMetadata(ctx, application.GitInformation.RepositoryOwner, application.GitInformation.RepositoryName, application.GitInformation.RepositoryBranch, application.MonitoringInformation.OpenApiPath, applicationToken)
-	if err != nil {
-		return fmt.Errorf("failed to get OpenAPI spec metadata for application %s: %v", application.Name, err)
+	_, err = s.updateApplicationOpenAPISpecification(ctx, application, snapshot)
+	return err
+}
+
+func (s *monitoringService) shouldUpdateOpenAPISpecification(application *model.Application) bool {
+	if application.GitInformation == nil {
+		s.logger.Infof("No git information for application %s, skipping OpenAPI spec update", application.Name)
+		return false // Could be an error because there is nothing to update.
 	}
 
-	if application.MonitoringInformation != nil && application.MonitoringInformation.OpenAPISha == openApiSpecMetadata.SHA {
-		s.logger.Infof("OpenAPI specification for application %s is up to date, skipping update", application.Name)
-		return nil
+	if application.MonitoringInformation == nil || !application.MonitoringInformation.HasOpenApi {
+		s.logger.Infof("Application %s does not have OpenAPI specification enabled, skipping OpenAPI spec update", application.Name)
+		return false
 	}
 
-	openAPISpecRaw, err := s.gitService.GetFileWithContent(ctx, application.GitInformation.RepositoryOwner, application.GitInformation.RepositoryName, application.GitInformation.RepositoryBranch, application.MonitoringInformation.OpenApiPath, applicationToken)
+	return true
+}
+
+func (s *monitoringService) updateApplicationOpenAPISpecification(ctx context.Context, application *model.Application, snapshot *repositorySnapshot) (string, error) {
+	openApiSpecMetadata, err := s.getSnapshotFileMetadata(application, snapshot, application.MonitoringInformation.OpenApiPath)
 	if err != nil {
-		s.logger.Errorf("Failed to get swagger.json for application %s: %v", application.Name, err)
-		return err
+		return "", err
 	}
 
-	if openApiSpecMetadata.SHA != openAPISpecRaw.Metadata.SHA {
-		return fmt.Errorf("SHA mismatch for swagger.json of application %s", application.Name)
+	if application.MonitoringInformation.OpenAPISha == openApiSpecMetadata.SHA {
+		s.logger.Infof("OpenAPI specification for application %s is up to date, skipping update", application.Name)
+		return openApiSpecMetadata.SHA, nil
 	}
 
-	openApiSpec, err := s.openApiService.ParseOpenApiSpec(openAPISpecRaw.Content)
+	applicationOpenApiObj, err := s.getOpenAPISpecification(ctx, application, snapshot, openApiSpecMetadata)
 	if err != nil {
-		s.logger.Errorf("Failed to parse OpenAPI spec for application %s: %v", application.Name, err)
-
+		return openApiSpecMetadata.SHA, err
 	}
 
-	applicationOpenApiObj, err := s.translator.ToApplicationOpenApiObj(openApiSpec)
+	previousApplicationOpenApiObj, err := s.getStoredOpenAPISpecification(ctx, application)
 	if err != nil {
-		return fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err)
+		return openApiSpecMetadata.SHA, err
 	}
 
-	previousApplicationOpenApiObj, err := s.storageService.GetOpenAPISpecificationByApplicationName(ctx, application.Name)
-	if err != nil && !errorUtils.Is(err, storage.ErrNotFound) {
-		return fmt.Errorf("failed to get existing OpenAPI spec for application %s: %v", application.Name, err)
-	}
+	commit := s.getCommit(ctx, application, snapshot)
+	version := s.translator.ToApplicationOpenAPIVersionObj(applicationOpenApiObj, openApiSpecMetadata.SHA, commit, time.Now())
 
-	err = s.storageService.UpsertOpenAPISpecification(ctx, application.Name, applicationOpenApiObj, openAPISpecRaw.Metadata.SHA)
+	err = s.storageService.UpsertOpenAPISpecification(ctx, application.Name, applicationOpenApiObj, openApiSpecMetadata.SHA, version)
 	if err != nil {
-		return fmt.Errorf("failed to upsert OpenAPI spec for application %s: %v", application.Name, err)
+		return openApiSpecMetadata.SHA, model.NewSyncError(model.SyncErrorCategoryStorage, fmt.Errorf("failed to upsert OpenAPI spec for application %s: %v", application.Name, err))
 	}
 
+	s.refreshContractDrifts(ctx, application, s.storageService.GetApplicationDependenciesByProvider)
+
 	if previousApplicationOpenApiObj != nil {
+		// The sync context is cancelled once the sync returns, the notification must not be dropped with it
+		notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), openAPIDifferencesNotificationTimeout)
 		go func() {
-			err := s.compareVersionsAndNotifyDifferences(ctx, application, previousApplicationOpenApiObj, applicationOpenApiObj)
+			defer cancel()
+			err := s.compareVersionsAndNotifyDifferences(notifyCtx, application, previousApplicationOpenApiObj, applicationOpenApiObj)
 			if err != nil {
 				s.logger.Errorf("Failed to compare OpenAPI spec versions for application and notify its users: %s: %v", application.Name, err)
 			}
 		}()
 	}
 
-	return nil
+	return openApiSpecMetadata.SHA, nil
 }
 
-func (s *monitoringService) compareVersionsAndNotifyDifferences(ctx context.Context, application *model.Application, previousSpec *obj.ApplicationOpenAPI, currentSpec *obj.ApplicationOpenAPI) error {
-	previousOpenApiModel, err := s.translator.ToApplicationOpenApiModel(previousSpec)
+// getCommit returns the commit of the snapshot, with only its sha when the git provider could not describe it. Missing
+// commit details are not worth failing the sync for.
+func (s *monitoringService) getCommit(ctx context.Context, application *model.Application, snapshot *repositorySnapshot) *model.GitCommit {
+	gitInformation := application.GitInformation
+
+	commit, err := snapshot.gitService.GetCommit(ctx, gitInformation.RepositoryOwner, gitInformation.RepositoryName, snapshot.commitSha, snapshot.token)
 	if err != nil {
-		return fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err)
+		s.logger.Warnf("Failed to get commit %s for application %s: %v", snapshot.commitSha, application.Name, err)
+		return &model.GitCommit{Sha: snapshot.commitSha}
 	}
 
-	currentOpenApiModel, err := s.translator.ToApplicationOpenApiModel(currentSpec)
+	return commit
+}
+
+func (s *monitoringService) getOpenAPISpecification(ctx context.Context, application *model.Application, snapshot *repositorySnapshot, openApiSpecMetadata *model.FileMetadata) (*obj.ApplicationOpenAPI, error) {
+	openAPISpecRaw, err := snapshot.gitService.GetFileWithContent(ctx, application.GitInformation.RepositoryOwner, application.GitInformation.RepositoryName, snapshot.commitSha, application.MonitoringInformation.OpenApiPath, snapshot.token)
 	if err != nil {
-		return fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err)
+		s.logger.Errorf("Failed to get swagger.json for application %s: %v", application.Name, err)
+		return nil, newGitFetchError(err)
 	}
 
-	changes, err := s.openApiService.CompareOpenApiSpecs(previousOpenApiModel.OpenAPISpec, currentOpenApiModel.OpenAPISpec)
+	if openApiSpecMetadata.SHA != openAPISpecRaw.Metadata.SHA {
+		return nil, model.NewSyncError(model.SyncErrorCategoryGitFetch, fmt.Errorf("SHA mismatch for swagger.json of application %s", application.Name))
+	}
+
+	openApiSpec, openAPIVersion, err := s.openApiService.ParseOpenApiSpec(openAPISpecRaw.Content)
+	if err != nil {
+		s.logger.Errorf("Failed to parse OpenAPI spec for application %s: %v", application.Name, err)
+		return nil, model.NewSyncError(model.SyncErrorCategoryParse, fmt.Errorf("failed to parse OpenAPI spec for application %s: %v", application.Name, err))
+	}
+
+	applicationOpenApiObj, err := s.translator.ToApplicationOpenApiObj(openApiSpec, openAPIVersion)
+	if err != nil {
+		return nil, model.NewSyncError(model.SyncErrorCategoryParse, fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err))
+	}
+
+	return applicationOpenApiObj, nil
+}
+
+// getStoredOpenAPISpecification returns nil when no OpenAPI specification was stored for the application yet
+func (s *monitoringService) getStoredOpenAPISpecification(ctx context.Context, application *model.Application) (*obj.ApplicationOpenAPI, error) {
+	storedApplicationOpenApiObj, err := s.storageService.GetOpenAPISpecificationByApplicationName(ctx, application.Name)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, model.NewSyncError(model.SyncErrorCategoryStorage, fmt.Errorf("failed to get existing OpenAPI spec for application %s: %v", application.Name, err))
+	}
+
+	return storedApplicationOpenApiObj, nil
+}
+
+func (s *monitoringService) compareVersionsAndNotifyDifferences(ctx context.Context, application *model.Application, previousSpec *obj.ApplicationOpenAPI, currentSpec *obj.ApplicationOpenAPI) error {
+	changes, err := s.compareOpenAPISpecifications(application, previousSpec, currentSpec)
 	if err != nil {
-		return fmt.Errorf("failed to compare OpenAPI specs for application %s: %v", application.Name, err)
+		return err
 	}
 
 	if len(changes) == 0 {
@@ -350,13 +583,38 @@ func (s *monitoringService) compareVersionsAndNotifyDifferences(ctx context.Cont
 		return fmt.Errorf("failed to get dependencies for application %s: %v", application.Name, err)
 	}
 
+	// Consumers declared the operations of the previous specification
+	previousOpenApiModel, err := s.translator.ToApplicationOpenApiModel(previousSpec)
+	if err != nil {
+		return fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err)
+	}
+
 	applicationDependencies := s.translator.ToModelAppEndpointDependencies(dependencies)
 
-	s.mailService.SendOpenAPIDifferencesNotification(ctx, application, applicationDependencies, changes)
+	s.mailService.SendOpenAPIDifferencesNotification(ctx, application, model.NewOperationMatcher(previousOpenApiModel.OpenAPISpec), applicationDependencies, changes)
 
 	return nil
 }
 
+func (s *monitoringService) compareOpenAPISpecifications(application *model.Application, previousSpec *obj.ApplicationOpenAPI, currentSpec *obj.ApplicationOpenAPI) (checker.Changes, error) {
+	previousOpenApiModel, err := s.translator.ToApplicationOpenApiModel(previousSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err)
+	}
+
+	currentOpenApiModel, err := s.translator.ToApplicationOpenApiModel(currentSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", application.Name, err)
+	}
+
+	changes, err := s.openApiService.CompareOpenApiSpecs(previousOpenApiModel.OpenAPISpec, currentOpenApiModel.OpenAPISpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare OpenAPI specs for application %s: %v", application.Name, err)
+	}
+
+	return changes, nil
+}
+
 func (s *monitoringService) GetApplicationOpenAPISpecification(ctx context.Context, application *model.Application) (*model.ApplicationOpenAPISpecification, error) {
 	if application.GitInformation == nil {
 		return nil, errors.NewNotFoundError("The application does not have a git repository associated with it")
@@ -379,113 +637,1310 @@ func (s *monitoringService) GetApplicationOpenAPISpecification(ctx context.Conte
 	return applicationOpenApiModel, nil
 }
 
-func (s *monitoringService) SentinelSettingsPresent(ctx context.Context) (bool, error) {
-	setting, err := s.storageService.GetSentinelSetting(ctx, SentinelSettingsName)
+func (s *monitoringService) GetApplicationOpenAPISpecificationVersions(ctx context.Context, application *model.Application, at *time.Time, page, pageSize int) (*model.ApplicationOpenAPIVersionsPage, error) {
+	if page < 1 {
+		return nil, errors.NewBadRequestError("page must be greater than 0")
+	}
+
+	if pageSize < 1 || pageSize > MaxOpenAPIVersionsPageSize {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("page size must be between 1 and %d", MaxOpenAPIVersionsPageSize))
+	}
+
+	versionObjs, total, err := s.storageService.GetOpenAPISpecificationVersions(ctx, application.Name, at, (page-1)*pageSize, pageSize)
 	if err != nil {
 		if errorUtils.Is(err, storage.ErrNotFound) {
-			return false, nil
+			return nil, errors.NewNotFoundError(fmt.Sprintf("application %s not found", application.Name))
 		}
-		return false, fmt.Errorf("failed to get sentinel setting: %v", err)
+		return nil, errors.NewInternalServerError("failed to retrieve OpenAPI specification versions: " + err.Error())
 	}
 
-	return setting != nil, nil
+	versions, err := s.translator.ToApplicationOpenAPIVersionModels(versionObjs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform OpenAPI spec versions for application %s: %v", application.Name, err)
+	}
+
+	return &model.ApplicationOpenAPIVersionsPage{
+		Versions: versions,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
 }
 
-func (s *monitoringService) InsertSentinelIntervalSetting(ctx context.Context, interval int, enabled bool) error {
-	setting := &obj.SentinelSetting{
-		Name:     SentinelSettingsName,
-		Interval: interval,
-		Enabled:  enabled,
+func (s *monitoringService) GetApplicationOpenAPISpecificationVersion(ctx context.Context, application *model.Application, versionID uint) (*model.ApplicationOpenAPIVersion, error) {
+	versionObj, err := s.storageService.GetOpenAPISpecificationVersion(ctx, application.Name, int(versionID))
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("OpenAPI specification version %d not found for application %s", versionID, application.Name))
+		}
+		return nil, errors.NewInternalServerError("failed to retrieve OpenAPI specification version: " + err.Error())
 	}
-	err := s.storageService.InsertSentinelSetting(ctx, setting)
+
+	version, err := s.translator.ToApplicationOpenAPIVersionModel(versionObj)
 	if err != nil {
-		return fmt.Errorf("failed to insert sentinel setting: %v", err)
+		return nil, fmt.Errorf("failed to transform OpenAPI spec version %d for application %s: %v", versionID, application.Name, err)
 	}
-	return nil
-}
 
-func (s *monitoringService) StoreSentinelChannel(newConfigChannel chan<- model.SentinelSettings) {
-	s.sentinelConfigChannel = newConfigChannel
+	return version, nil
 }
 
-func (s *monitoringService) UpdateSentinelSettings(ctx context.Context, sentinelSettingsUpdate *model.SentinelSettingsUpdate) error {
-	if sentinelSettingsUpdate != nil && sentinelSettingsUpdate.Interval != nil {
-		if *sentinelSettingsUpdate.Interval < s.sentinelMinIntervalSeconds || *sentinelSettingsUpdate.Interval > s.sentinelMaxIntervalSeconds {
-			return errors.NewBadRequestError(fmt.Sprintf("Interval must be between %d and %d seconds", s.sentinelMinIntervalSeconds, s.sentinelMaxIntervalSeconds))
+// GetApplicationOpenAPIRetention returns a retention without limits when none was configured for the application
+func (s *monitoringService) GetApplicationOpenAPIRetention(ctx context.Context, application *model.Application) (*model.OpenAPIRetention, error) {
+	retentionObj, err := s.storageService.GetOpenAPISpecificationRetention(ctx, application.Name)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return &model.OpenAPIRetention{}, nil
 		}
+		return nil, errors.NewInternalServerError("failed to retrieve OpenAPI specification retention: " + err.Error())
 	}
 
-	existingSetting, err := s.storageService.GetSentinelSetting(context.Background(), SentinelSettingsName)
+	return s.translator.ToOpenAPIRetentionModel(retentionObj), nil
+}
+
+func (s *monitoringService) UpdateApplicationOpenAPIRetention(ctx context.Context, application *model.Application, retention *model.OpenAPIRetention) error {
+	if retention.MaxVersions != nil && *retention.MaxVersions < 1 {
+		return errors.NewBadRequestError("the maximum number of versions must be greater than 0")
+	}
+
+	if retention.MaxAgeDays != nil && *retention.MaxAgeDays < 1 {
+		return errors.NewBadRequestError("the maximum age in days must be greater than 0")
+	}
+
+	err := s.storageService.UpsertOpenAPISpecificationRetention(ctx, application.Name, s.translator.ToOpenAPIRetentionObj(retention), time.Now())
 	if err != nil {
 		if errorUtils.Is(err, storage.ErrNotFound) {
-			return errors.NewNotFoundError("Sentinel settings not found")
+			return errors.NewNotFoundError(fmt.Sprintf("application %s not found", application.Name))
 		}
-		return fmt.Errorf("failed to get existing sentinel setting: %v", err)
+		return errors.NewInternalServerError("failed to update OpenAPI specification retention: " + err.Error())
 	}
 
-	updateObj := &obj.SentinelSetting{
-		CosmosObj: obj.CosmosObj{
-			ID:        existingSetting.ID,
-			CreatedAt: existingSetting.CreatedAt,
-		},
-		Name:     existingSetting.Name,
-		Interval: existingSetting.Interval,
-		Enabled:  existingSetting.Enabled,
-	}
+	return nil
+}
 
-	fmt.Printf("Current settings: %+v\n", updateObj)
+func (s *monitoringService) CompareApplicationOpenAPISpecifications(ctx context.Context, application *model.Application, base, revision *model.OpenAPISpecificationReference, format string) (*model.OpenAPIDiff, error) {
+	if format != "" && format != model.OpenAPIDiffFormatHTML && format != model.OpenAPIDiffFormatMarkdown {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("format must be %s or %s", model.OpenAPIDiffFormatHTML, model.OpenAPIDiffFormatMarkdown))
+	}
 
-	if sentinelSettingsUpdate.Enabled != nil {
-		updateObj.Enabled = *sentinelSettingsUpdate.Enabled
+	baseSpec, err := s.getReferencedOpenAPISpecification(ctx, application, base)
+	if err != nil {
+		return nil, err
 	}
 
-	if sentinelSettingsUpdate.Interval != nil {
-		updateObj.Interval = *sentinelSettingsUpdate.Interval
+	revisionSpec, err := s.getReferencedOpenAPISpecification(ctx, application, revision)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Updated settings: %+v\n", updateObj)
+	changes, err := s.openApiService.CompareOpenApiSpecs(baseSpec, revisionSpec)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to compare OpenAPI specs for application %s: %v", application.Name, err))
+	}
 
-	err = s.storageService.UpdateSentinelSetting(ctx, updateObj)
+	dependencies, err := s.storageService.GetApplicationDependenciesByProvider(ctx, application.Name)
 	if err != nil {
-		return fmt.Errorf("failed to update sentinel setting: %v", err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get consumers of application %s: %v", application.Name, err))
 	}
 
-	if s.sentinelConfigChannel != nil {
-		newSettings := model.SentinelSettings{
-			Interval: updateObj.Interval,
-			Enabled:  updateObj.Enabled,
+	diff := &model.OpenAPIDiff{
+		Application: application,
+		Base:        base,
+		Revision:    revision,
+		Changes:     s.translator.ToOpenAPIChangeModels(changes),
+		Format:      format,
+	}
+	setAffectedConsumers(diff.Changes, model.NewOperationMatcher(baseSpec), s.translator.ToModelAppEndpointDependencies(dependencies))
+
+	if format != "" {
+		diff.Rendered, err = renderOpenAPIChanges(changes, format, getOpenAPISpecificationReferenceLabel(base), getOpenAPISpecificationReferenceLabel(revision))
+		if err != nil {
+			return nil, errors.NewInternalServerError(fmt.Sprintf("failed to render OpenAPI changes for application %s: %v", application.Name, err))
 		}
-		s.sentinelConfigChannel <- newSettings
 	}
 
-	return nil
+	return diff, nil
 }
 
-func (s *monitoringService) GetSentinelSettings(ctx context.Context) (*model.SentinelSettings, error) {
-	settingObj, err := s.storageService.GetSentinelSetting(ctx, SentinelSettingsName)
+func (s *monitoringService) CheckApplicationOpenAPISpecification(ctx context.Context, application *model.Application, candidateSpec string, failOn string) (*model.OpenAPICheck, error) {
+	if failOn == "" {
+		failOn = model.OpenAPIChangeLevelError
+	}
+
+	if model.OpenAPIChangeLevelSeverity(failOn) == 0 {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("failOn must be %s, %s or %s", model.OpenAPIChangeLevelError, model.OpenAPIChangeLevelWarning, model.OpenAPIChangeLevelInfo))
+	}
+
+	candidateOpenApiSpec, _, err := s.openApiService.ParseOpenApiSpec(candidateSpec)
 	if err != nil {
-		if errorUtils.Is(err, storage.ErrNotFound) {
-			return nil, errors.NewNotFoundError("Sentinel settings not found")
-		}
-		return nil, fmt.Errorf("failed to get sentinel setting: %v", err)
+		return nil, errors.NewBadRequestError(fmt.Sprintf("failed to parse the candidate OpenAPI spec of application %s: %v", application.Name, err))
 	}
 
-	return s.translator.ToSentinelSettingsModel(settingObj), nil
-}
+	check := &model.OpenAPICheck{
+		Application: application,
+		FailOn:      failOn,
+		Passed:      true,
+		Changes:     make([]*model.OpenAPIChange, 0),
+	}
 
-func (s *monitoringService) GetGroupApplicationsInteractions(ctx context.Context, groupName string) (*model.ApplicationsInteractions, error) {
-	groupObj, err := s.storageService.GetGroupByName(ctx, groupName)
+	storedApplicationOpenApiObj, err := s.getStoredOpenAPISpecification(ctx, application)
 	if err != nil {
-		if errorUtils.Is(err, storage.ErrNotFound) {
-			return nil, errors.NewNotFoundError(fmt.Sprintf("group %s not found", groupName))
-		}
-		return nil, fmt.Errorf("failed to get group %s: %v", groupName, err)
+		return nil, errors.NewInternalServerError(err.Error())
 	}
 
-	objDependencies, err := s.storageService.GetApplicationDependenciesFromGroup(ctx, groupObj)
+	// Without a stored specification no consumer can rely on any operation yet
+	if storedApplicationOpenApiObj == nil {
+		return check, nil
+	}
+	check.HasStoredSpecification = true
+
+	storedOpenApiModel, err := s.translator.ToApplicationOpenApiModel(storedApplicationOpenApiObj)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to transform OpenAPI spec for application %s: %v", application.Name, err))
 	}
 
-	return s.translator.ToApplicationsInteractionsModel(objDependencies), nil
+	changes, err := s.openApiService.CompareOpenApiSpecs(storedOpenApiModel.OpenAPISpec, candidateOpenApiSpec)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to compare OpenAPI specs for application %s: %v", application.Name, err))
+	}
+
+	dependencies, err := s.storageService.GetApplicationDependenciesByProvider(ctx, application.Name)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get consumers of application %s: %v", application.Name, err))
+	}
+
+	modelChanges := s.translator.ToOpenAPIChangeModels(changes)
+	setAffectedConsumers(modelChanges, model.NewOperationMatcher(storedOpenApiModel.OpenAPISpec), s.translator.ToModelAppEndpointDependencies(dependencies))
+
+	failOnSeverity := model.OpenAPIChangeLevelSeverity(failOn)
+	for _, change := range modelChanges {
+		if len(change.AffectedConsumers) == 0 {
+			check.IgnoredChangesCount++
+			continue
+		}
+
+		check.Changes = append(check.Changes, change)
+		if model.OpenAPIChangeLevelSeverity(change.Level) >= failOnSeverity {
+			check.Passed = false
+		}
+	}
+
+	return check, nil
+}
+
+func (s *monitoringService) ValidateApplicationOpenClientSpecification(ctx context.Context, application *model.Application, candidateSpec string) (*model.OpenClientValidation, error) {
+	validation := &model.OpenClientValidation{
+		Application:  application,
+		Valid:        true,
+		Errors:       make([]string, 0),
+		Dependencies: make([]*model.OpenClientDependencyValidation, 0),
+	}
+
+	openClientDef, err := s.transformToOpenClientDefinition(&model.FileContent{Content: candidateSpec})
+	if err != nil {
+		validation.Valid = false
+		validation.Errors = append(validation.Errors, err.Error())
+		return validation, nil
+	}
+
+	dependencyNames := make([]string, 0, len(openClientDef.Dependencies))
+	for dependencyName := range openClientDef.Dependencies {
+		dependencyNames = append(dependencyNames, dependencyName)
+	}
+	sort.Strings(dependencyNames)
+
+	for _, dependencyName := range dependencyNames {
+		dependencyValidation, err := s.validateOpenClientDependency(ctx, application, dependencyName, openClientDef.Dependencies[dependencyName])
+		if err != nil {
+			return nil, errors.NewInternalServerError(err.Error())
+		}
+
+		if len(dependencyValidation.Errors) > 0 {
+			validation.Valid = false
+		}
+		validation.Dependencies = append(validation.Dependencies, dependencyValidation)
+	}
+
+	return validation, nil
+}
+
+// validateOpenClientDependency checks that the provider of a dependency is registered and declares every endpoint
+// the dependency relies on
+func (s *monitoringService) validateOpenClientDependency(ctx context.Context, application *model.Application, dependencyName string, dependency model.DependencySpecification) (*model.OpenClientDependencyValidation, error) {
+	dependencyValidation := &model.OpenClientDependencyValidation{
+		Name:     dependencyName,
+		Errors:   make([]string, 0),
+		Warnings: make([]string, 0),
+	}
+
+	if dependencyName == application.Name {
+		dependencyValidation.Errors = append(dependencyValidation.Errors, fmt.Sprintf("application %s cannot depend on itself", application.Name))
+		return dependencyValidation, nil
+	}
+
+	providerObj, err := s.storageService.GetApplicationWithName(ctx, dependencyName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			dependencyValidation.Pending = true
+			dependencyValidation.Warnings = append(dependencyValidation.Warnings, fmt.Sprintf("application %s is not registered, the dependency stays pending until it is", dependencyName))
+			return dependencyValidation, nil
+		}
+		return nil, fmt.Errorf("failed to get dependency application %s for application %s: %v", dependencyName, application.Name, err)
+	}
+
+	provider := s.translator.ToApplicationModel(providerObj)
+
+	storedApplicationOpenApiObj, err := s.getStoredOpenAPISpecification(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if storedApplicationOpenApiObj == nil {
+		if len(dependency.Endpoints) > 0 {
+			dependencyValidation.Warnings = append(dependencyValidation.Warnings, fmt.Sprintf("application %s has no OpenAPI specification stored, its endpoints cannot be checked", dependencyName))
+		}
+		return dependencyValidation, nil
+	}
+
+	storedOpenApiModel, err := s.translator.ToApplicationOpenApiModel(storedApplicationOpenApiObj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform OpenAPI spec for application %s: %v", dependencyName, err)
+	}
+
+	paths := make([]string, 0, len(dependency.Endpoints))
+	for path := range dependency.Endpoints {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	matcher := model.NewOperationMatcher(storedOpenApiModel.OpenAPISpec)
+
+	for _, path := range paths {
+		_, pathItem := matcher.FindPath(path)
+		if pathItem == nil {
+			dependencyValidation.Errors = append(dependencyValidation.Errors, fmt.Sprintf("path %s is not declared in the OpenAPI specification of application %s", path, dependencyName))
+			continue
+		}
+
+		methods := make([]string, 0, len(dependency.Endpoints[path]))
+		for method := range dependency.Endpoints[path] {
+			methods = append(methods, strings.ToUpper(method))
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			operation := pathItem.GetOperation(method)
+			if operation == nil {
+				dependencyValidation.Errors = append(dependencyValidation.Errors, fmt.Sprintf("method %s of path %s is not declared in the OpenAPI specification of application %s", method, path, dependencyName))
+				continue
+			}
+
+			if operation.Deprecated {
+				dependencyValidation.Warnings = append(dependencyValidation.Warnings, fmt.Sprintf("method %s of path %s is deprecated by application %s", method, path, dependencyName))
+			}
+		}
+	}
+
+	return dependencyValidation, nil
+}
+
+// refreshContractDrifts recomputes the drifts of the dependencies of the application returned by getDependencies. The
+// report is not worth failing the sync for, it is recomputed on the next sync of either side.
+func (s *monitoringService) refreshContractDrifts(ctx context.Context, application *model.Application, getDependencies func(ctx context.Context, applicationName string) ([]*obj.ApplicationDependency, error)) {
+	dependencies, err := getDependencies(ctx, application.Name)
+	if err != nil {
+		s.logger.Errorf("Failed to get dependencies to refresh the contract drifts of application %s: %v", application.Name, err)
+		return
+	}
+
+	dependencyIDs := make([]int, 0, len(dependencies))
+	drifts := make([]*obj.ContractDrift, 0)
+	providerMatchers := make(map[string]*model.OperationMatcher)
+
+	for _, dependency := range dependencies {
+		if dependency.Provider == nil {
+			continue
+		}
+		dependencyIDs = append(dependencyIDs, int(dependency.ID))
+
+		providerMatcher, ok := providerMatchers[dependency.Provider.Name]
+		if !ok {
+			providerSpec, err := s.getContractDriftProviderSpecification(ctx, dependency.Provider.Name)
+			if err != nil {
+				s.logger.Errorf("Failed to refresh the contract drifts of application %s: %v", application.Name, err)
+				return
+			}
+
+			// Without a stored specification nothing tells which endpoints the provider has
+			if providerSpec != nil {
+				providerMatcher = model.NewOperationMatcher(providerSpec)
+			}
+			providerMatchers[dependency.Provider.Name] = providerMatcher
+		}
+
+		if providerMatcher == nil {
+			continue
+		}
+
+		for path, methods := range dependency.Endpoints {
+			for method := range methods {
+				if providerMatcher.FindOperation(method, path) == nil {
+					drifts = append(drifts, &obj.ContractDrift{DependencyID: int(dependency.ID), Path: path, Method: strings.ToUpper(method)})
+				}
+			}
+		}
+	}
+
+	if len(dependencyIDs) == 0 {
+		return
+	}
+
+	if err := s.storageService.UpdateContractDrifts(ctx, dependencyIDs, drifts, time.Now()); err != nil {
+		s.logger.Errorf("Failed to store the contract drifts of application %s: %v", application.Name, err)
+	}
+}
+
+// getContractDriftProviderSpecification returns nil when the provider has no OpenAPI specification stored
+func (s *monitoringService) getContractDriftProviderSpecification(ctx context.Context, providerName string) (*openapi3.T, error) {
+	storedApplicationOpenApiObj, err := s.storageService.GetOpenAPISpecificationByApplicationName(ctx, providerName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get OpenAPI spec of application %s: %v", providerName, err)
+	}
+
+	storedOpenApiModel, err := s.translator.ToApplicationOpenApiModel(storedApplicationOpenApiObj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform OpenAPI spec of application %s: %v", providerName, err)
+	}
+
+	if storedOpenApiModel.OpenAPISpec == nil || storedOpenApiModel.OpenAPISpec.Paths == nil {
+		return nil, nil
+	}
+
+	return storedOpenApiModel.OpenAPISpec, nil
+}
+
+func (s *monitoringService) GetApplicationContractDrifts(ctx context.Context, application *model.Application) ([]*model.ContractDrift, error) {
+	drifts, err := s.storageService.GetContractDriftsWithApplicationInvolved(ctx, application.Name)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("application %s not found", application.Name))
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get contract drifts of application %s: %v", application.Name, err))
+	}
+
+	return s.translator.ToContractDriftModels(drifts), nil
+}
+
+func (s *monitoringService) GetContractDrifts(ctx context.Context) ([]*model.ContractDrift, error) {
+	drifts, err := s.storageService.GetAllContractDrifts(ctx)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get contract drifts: %v", err))
+	}
+
+	return s.translator.ToContractDriftModels(drifts), nil
+}
+
+func (s *monitoringService) GetProviderOperations(ctx context.Context, provider *model.Application, filter model.EndpointConsumerFilter) ([]*model.ProviderOperation, error) {
+	if filter.Method != "" && !model.IsValidHTTPMethod(filter.Method) {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("invalid HTTP method %s", filter.Method))
+	}
+
+	if filter.Path != "" && !strings.HasPrefix(filter.Path, "/") {
+		return nil, errors.NewBadRequestError("path must start with '/'")
+	}
+
+	endpointConsumers, err := s.storageService.GetEndpointConsumers(ctx, provider.Name, filter.Method)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("application %s not found", provider.Name))
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get consumers of the operations of application %s: %v", provider.Name, err))
+	}
+
+	if filter.Path != "" {
+		// The declared paths are matched the way drift detection and notifications match them, base paths included
+		spec, err := s.getContractDriftProviderSpecification(ctx, provider.Name)
+		if err != nil {
+			return nil, errors.NewInternalServerError(err.Error())
+		}
+
+		endpointConsumers = filterEndpointConsumersByPath(endpointConsumers, model.NewOperationMatcher(spec), filter.Path)
+	}
+
+	return s.translator.ToProviderOperationModels(endpointConsumers), nil
+}
+
+func filterEndpointConsumersByPath(endpointConsumers []*obj.EndpointConsumer, matcher *model.OperationMatcher, path string) []*obj.EndpointConsumer {
+	matchingEndpointConsumers := make([]*obj.EndpointConsumer, 0, len(endpointConsumers))
+	for _, endpointConsumer := range endpointConsumers {
+		if matcher.MatchesPath(endpointConsumer.Path, path) {
+			matchingEndpointConsumers = append(matchingEndpointConsumers, endpointConsumer)
+		}
+	}
+
+	return matchingEndpointConsumers
+}
+
+func (s *monitoringService) GetApplicationUnusedOperations(ctx context.Context, application *model.Application) (*model.UnusedOperationsReport, error) {
+	report := &model.UnusedOperationsReport{
+		Application:                application,
+		UnusedOperations:           make([]*model.UnusedOperation, 0),
+		DeprecatedUnusedOperations: make([]*model.UnusedOperation, 0),
+	}
+
+	storedApplicationOpenApiObj, err := s.getStoredOpenAPISpecification(ctx, application)
+	if err != nil {
+		return nil, errors.NewInternalServerError(err.Error())
+	}
+
+	if storedApplicationOpenApiObj == nil {
+		return report, nil
+	}
+	report.HasStoredSpecification = true
+
+	storedOpenApiModel, err := s.translator.ToApplicationOpenApiModel(storedApplicationOpenApiObj)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to transform OpenAPI spec for application %s: %v", application.Name, err))
+	}
+
+	if storedOpenApiModel.OpenAPISpec == nil || storedOpenApiModel.OpenAPISpec.Paths == nil {
+		return report, nil
+	}
+
+	dependencies, err := s.storageService.GetApplicationDependenciesByProvider(ctx, application.Name)
+	if err != nil {
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get consumers of application %s: %v", application.Name, err))
+	}
+
+	matcher := model.NewOperationMatcher(storedOpenApiModel.OpenAPISpec)
+	applicationDependencies := s.translator.ToModelAppEndpointDependencies(dependencies)
+
+	paths := make([]string, 0, storedOpenApiModel.OpenAPISpec.Paths.Len())
+	for path := range storedOpenApiModel.OpenAPISpec.Paths.Map() {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		operations := storedOpenApiModel.OpenAPISpec.Paths.Value(path).Operations()
+
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			if isOperationDeclared(matcher, applicationDependencies, method, path) {
+				continue
+			}
+
+			operation := operations[method]
+			unusedOperation := &model.UnusedOperation{
+				Method:      method,
+				Path:        path,
+				OperationID: operation.OperationID,
+				Summary:     operation.Summary,
+				Deprecated:  operation.Deprecated,
+			}
+
+			if operation.Deprecated {
+				report.DeprecatedUnusedOperations = append(report.DeprecatedUnusedOperations, unusedOperation)
+			} else {
+				report.UnusedOperations = append(report.UnusedOperations, unusedOperation)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (s *monitoringService) GetApplicationsUnusedOperations(ctx context.Context, applications []*model.Application) ([]*model.UnusedOperationsReport, error) {
+	reports := make([]*model.UnusedOperationsReport, 0, len(applications))
+	for _, application := range applications {
+		report, err := s.GetApplicationUnusedOperations(ctx, application)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (s *monitoringService) getReferencedOpenAPISpecification(ctx context.Context, application *model.Application, reference *model.OpenAPISpecificationReference) (*openapi3.T, error) {
+	if reference.VersionID != nil {
+		version, err := s.GetApplicationOpenAPISpecificationVersion(ctx, application, *reference.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		return version.OpenAPISpec, nil
+	}
+
+	if application.GitInformation == nil || application.MonitoringInformation == nil || application.MonitoringInformation.OpenApiPath == "" {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("application %s has no OpenAPI specification in a git repository to read %s from", application.Name, reference.GitRef))
+	}
+
+	gitService, err := s.getGitService(application)
+	if err != nil {
+		return nil, errors.NewBadRequestError(err.Error())
+	}
+
+	applicationToken, err := s.getApplicationToken(application)
+	if err != nil {
+		return nil, errors.NewInternalServerError(err.Error())
+	}
+
+	gitInformation := application.GitInformation
+	openAPISpecRaw, err := gitService.GetFileWithContent(ctx, gitInformation.RepositoryOwner, gitInformation.RepositoryName, reference.GitRef, application.MonitoringInformation.OpenApiPath, applicationToken)
+	if err != nil {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("failed to read the OpenAPI spec of application %s at %s: %v", application.Name, reference.GitRef, err))
+	}
+
+	openApiSpec, _, err := s.openApiService.ParseOpenApiSpec(openAPISpecRaw.Content)
+	if err != nil {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("failed to parse the OpenAPI spec of application %s at %s: %v", application.Name, reference.GitRef, err))
+	}
+
+	return openApiSpec, nil
+}
+
+func isOperationDeclared(matcher *model.OperationMatcher, applicationDependencies []*model.AppEndpointDependencies, method, path string) bool {
+	for _, applicationDependency := range applicationDependencies {
+		if applicationDependency.UsesOperation(matcher, method, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func setAffectedConsumers(changes []*model.OpenAPIChange, matcher *model.OperationMatcher, applicationDependencies []*model.AppEndpointDependencies) {
+	for _, change := range changes {
+		for _, applicationDependency := range applicationDependencies {
+			if applicationDependency.Application != nil && applicationDependency.UsesOperation(matcher, change.Operation, change.Path) {
+				change.AffectedConsumers = append(change.AffectedConsumers, applicationDependency.Application.Name)
+			}
+		}
+		sort.Strings(change.AffectedConsumers)
+	}
+}
+
+func getOpenAPISpecificationReferenceLabel(reference *model.OpenAPISpecificationReference) string {
+	if reference.VersionID != nil {
+		return fmt.Sprintf("version %d", *reference.VersionID)
+	}
+
+	return reference.GitRef
+}
+
+func renderOpenAPIChanges(changes checker.Changes, format, baseLabel, revisionLabel string) (string, error) {
+	formatter, err := formatters.Lookup(format, formatters.FormatterOpts{
+		Language: "en",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := formatter.RenderChangelog(changes, formatters.NewRenderOpts(), baseLabel, revisionLabel)
+	if err != nil {
+		return "", err
+	}
+
+	return string(rendered), nil
+}
+
+func (s *monitoringService) PreviewApplicationSync(ctx context.Context, application *model.Application, changes *model.ApplicationUpdate) (*model.SyncPreview, error) {
+	previewedApplication, err := s.getPreviewedApplication(ctx, application, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	previewDependencies := s.shouldUpdateDependencies(previewedApplication)
+	previewOpenAPISpecification := s.shouldUpdateOpenAPISpecification(previewedApplication)
+
+	preview := &model.SyncPreview{Application: previewedApplication}
+	paths := make([]string, 0, 2)
+	if previewDependencies {
+		preview.Dependencies = &model.DependenciesSyncPreview{}
+		paths = append(paths, previewedApplication.MonitoringInformation.OpenClientPath)
+	}
+	if previewOpenAPISpecification {
+		preview.OpenAPI = &model.OpenAPISyncPreview{}
+		paths = append(paths, previewedApplication.MonitoringInformation.OpenApiPath)
+	}
+	if len(paths) == 0 {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("application %s monitors neither an openclient.json file nor an OpenAPI specification, there is nothing to sync", application.Name))
+	}
+
+	snapshot, err := s.getRepositorySnapshot(ctx, previewedApplication, paths...)
+	if err != nil {
+		// A repository that cannot be read is one of the misconfigurations the preview is meant to show
+		if preview.Dependencies != nil {
+			preview.Dependencies.Err = err
+		}
+		if preview.OpenAPI != nil {
+			preview.OpenAPI.Err = err
+		}
+		return preview, nil
+	}
+
+	preview.CommitSha = snapshot.commitSha
+
+	// The stored SHAs are ignored, the preview always shows the complete result of a sync at the head commit
+	if preview.Dependencies != nil {
+		s.previewApplicationDependencies(ctx, previewedApplication, snapshot, preview.Dependencies)
+	}
+	if preview.OpenAPI != nil {
+		s.previewApplicationOpenAPISpecification(ctx, previewedApplication, snapshot, preview.OpenAPI)
+	}
+
+	return preview, nil
+}
+
+// getPreviewedApplication applies the changes on a copy of the application, the stored application is left untouched
+func (s *monitoringService) getPreviewedApplication(ctx context.Context, application *model.Application, changes *model.ApplicationUpdate) (*model.Application, error) {
+	previewedApplication := *application
+	if changes == nil {
+		return &previewedApplication, nil
+	}
+
+	if changes.GitInformation != nil {
+		previewedApplication.GitInformation = changes.GitInformation
+	}
+
+	if changes.MonitoringInformation != nil {
+		var monitoringInformation model.MonitoringInformation
+		if application.MonitoringInformation != nil {
+			monitoringInformation = *application.MonitoringInformation
+		}
+		monitoringInformation.HasOpenApi = changes.MonitoringInformation.HasOpenApi
+		monitoringInformation.OpenApiPath = changes.MonitoringInformation.OpenApiPath
+		monitoringInformation.HasOpenClient = changes.MonitoringInformation.HasOpenClient
+		monitoringInformation.OpenClientPath = changes.MonitoringInformation.OpenClientPath
+		previewedApplication.MonitoringInformation = &monitoringInformation
+	}
+
+	if changes.TokenName != nil {
+		previewedApplication.Token = nil
+		if *changes.TokenName != "" {
+			applicationObj, err := s.storageService.GetApplicationWithName(ctx, application.Name)
+			if err != nil {
+				return nil, errors.NewInternalServerError("failed to retrieve application: " + err.Error())
+			}
+			if applicationObj.TeamID == nil {
+				return nil, errors.NewBadRequestError("Cannot associate a token to an application without a team")
+			}
+
+			tokenObj, err := s.storageService.GetTokenWithNameAndTeamID(ctx, *changes.TokenName, *applicationObj.TeamID)
+			if err != nil {
+				if errorUtils.Is(err, storage.ErrNotFound) {
+					return nil, errors.NewNotFoundError("token not found")
+				}
+				return nil, errors.NewInternalServerError("failed to retrieve token: " + err.Error())
+			}
+			previewedApplication.Token = s.translator.ToModelToken(tokenObj)
+		}
+	}
+
+	return &previewedApplication, nil
+}
+
+func (s *monitoringService) previewApplicationDependencies(ctx context.Context, application *model.Application, snapshot *repositorySnapshot, preview *model.DependenciesSyncPreview) {
+	openClientMetadata, err := s.getSnapshotFileMetadata(application, snapshot, application.MonitoringInformation.OpenClientPath)
+	if err != nil {
+		preview.Err = err
+		return
+	}
+	preview.Sha = openClientMetadata.SHA
+
+	changes, err := s.getDependenciesChanges(ctx, application, snapshot, openClientMetadata)
+	if err != nil {
+		preview.Err = err
+		return
+	}
+
+	preview.DependenciesToUpsert = make([]*model.ApplicationDependency, 0, len(changes.dependenciesToUpsert))
+	for _, dependency := range changes.dependenciesToUpsert {
+		preview.DependenciesToUpsert = append(preview.DependenciesToUpsert, dependency)
+	}
+	sort.Slice(preview.DependenciesToUpsert, func(i, j int) bool {
+		return preview.DependenciesToUpsert[i].Provider.Name < preview.DependenciesToUpsert[j].Provider.Name
+	})
+
+	preview.PendingDependencies = make([]*model.PendingApplicationDependency, 0, len(changes.pendingDependencies))
+	for _, pendingDependency := range changes.pendingDependencies {
+		preview.PendingDependencies = append(preview.PendingDependencies, pendingDependency)
+	}
+	sort.Slice(preview.PendingDependencies, func(i, j int) bool {
+		return preview.PendingDependencies[i].ProviderName < preview.PendingDependencies[j].ProviderName
+	})
+
+	preview.DependenciesToDelete = make([]*model.ApplicationDependency, 0, len(changes.dependenciesToDelete))
+	for _, dependency := range changes.dependenciesToDelete {
+		preview.DependenciesToDelete = append(preview.DependenciesToDelete, s.translator.ToApplicationDependencyModel(dependency))
+	}
+}
+
+func (s *monitoringService) previewApplicationOpenAPISpecification(ctx context.Context, application *model.Application, snapshot *repositorySnapshot, preview *model.OpenAPISyncPreview) {
+	openApiSpecMetadata, err := s.getSnapshotFileMetadata(application, snapshot, application.MonitoringInformation.OpenApiPath)
+	if err != nil {
+		preview.Err = err
+		return
+	}
+	preview.Sha = openApiSpecMetadata.SHA
+
+	applicationOpenApiObj, err := s.getOpenAPISpecification(ctx, application, snapshot, openApiSpecMetadata)
+	if err != nil {
+		preview.Err = err
+		return
+	}
+
+	storedApplicationOpenApiObj, err := s.getStoredOpenAPISpecification(ctx, application)
+	if err != nil {
+		preview.Err = err
+		return
+	}
+	if storedApplicationOpenApiObj == nil {
+		return
+	}
+	preview.HasStoredSpecification = true
+
+	changes, err := s.compareOpenAPISpecifications(application, storedApplicationOpenApiObj, applicationOpenApiObj)
+	if err != nil {
+		preview.Err = model.NewSyncError(model.SyncErrorCategoryParse, err)
+		return
+	}
+
+	preview.Changes = s.translator.ToOpenAPIChangeModels(changes)
+}
+
+func (s *monitoringService) SentinelSettingsPresent(ctx context.Context) (bool, error) {
+	setting, err := s.storageService.GetSentinelSetting(ctx, SentinelSettingsName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get sentinel setting: %v", err)
+	}
+
+	return setting != nil, nil
+}
+
+func (s *monitoringService) InsertSentinelIntervalSetting(ctx context.Context, interval int, enabled bool) error {
+	setting := &obj.SentinelSetting{
+		Name:     SentinelSettingsName,
+		Interval: interval,
+		Enabled:  enabled,
+	}
+	err := s.storageService.InsertSentinelSetting(ctx, setting)
+	if err != nil {
+		return fmt.Errorf("failed to insert sentinel setting: %v", err)
+	}
+	return nil
+}
+
+func (s *monitoringService) StoreSentinelChannel(newConfigChannel chan<- model.SentinelSettings) {
+	s.sentinelConfigChannel = newConfigChannel
+}
+
+func (s *monitoringService) StoreSentinelWorkersProvider(workersProvider func() []*model.SentinelWorker) {
+	s.sentinelWorkersProvider = workersProvider
+}
+
+// GetSentinelWorkers returns the workers of this replica, the queue is shared by every replica
+func (s *monitoringService) GetSentinelWorkers(ctx context.Context) (*model.SentinelWorkers, error) {
+	queueDepth, err := s.storageService.CountQueuedSyncJobs(ctx)
+	if err != nil {
+		return nil, errors.NewInternalServerError(err.Error())
+	}
+
+	workers := make([]*model.SentinelWorker, 0)
+	if s.sentinelWorkersProvider != nil {
+		workers = s.sentinelWorkersProvider()
+	}
+
+	return &model.SentinelWorkers{
+		Workers:    workers,
+		QueueDepth: queueDepth,
+	}, nil
+}
+
+// EnqueueApplicationsSync starts a run and queues a sync job for each application, the jobs are processed by the
+// sentinel workers of any replica. The returned jobs include the ones that were already waiting for an application
+func (s *monitoringService) EnqueueApplicationsSync(ctx context.Context, trigger string, applications []*model.Application) ([]*model.SyncJob, error) {
+	if len(applications) == 0 {
+		return []*model.SyncJob{}, nil
+	}
+
+	sentinelRun, err := s.StartSentinelRun(ctx, trigger)
+	if err != nil {
+		return nil, errors.NewInternalServerError(err.Error())
+	}
+
+	applicationNames := make([]string, 0, len(applications))
+	for _, application := range applications {
+		applicationNames = append(applicationNames, application.Name)
+	}
+
+	jobObjs, err := s.storageService.EnqueueSyncJobs(ctx, int(sentinelRun.ID), trigger, applicationNames, time.Now())
+	if err != nil {
+		return nil, errors.NewInternalServerError("failed to enqueue applications sync: " + err.Error())
+	}
+	jobs := s.translator.ToSyncJobModels(jobObjs)
+
+	queued := 0
+	for _, job := range jobs {
+		if job.RunID != nil && *job.RunID == sentinelRun.ID {
+			queued++
+		}
+	}
+
+	// The applications were already waiting in the queue, the jobs queued first sync them
+	if queued == 0 {
+		if err := s.FinishSentinelRun(ctx, sentinelRun.ID); err != nil {
+			return nil, errors.NewInternalServerError(err.Error())
+		}
+		return jobs, nil
+	}
+
+	s.logger.Infof("Queued %d of %d applications for a %s sync in run %d", queued, len(applications), trigger, sentinelRun.ID)
+
+	return jobs, nil
+}
+
+func (s *monitoringService) GetSyncJob(ctx context.Context, jobID uint) (*model.SyncJob, error) {
+	jobObj, err := s.storageService.GetSyncJob(ctx, int(jobID))
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("sync job %d not found", jobID))
+		}
+		return nil, errors.NewInternalServerError("failed to retrieve sync job: " + err.Error())
+	}
+
+	return s.translator.ToSyncJobModel(jobObj), nil
+}
+
+// ClaimSyncJob returns the next job to process, or nil when there is none available
+func (s *monitoringService) ClaimSyncJob(ctx context.Context, holder string) (*model.SyncJob, error) {
+	jobObj, err := s.storageService.ClaimSyncJob(ctx, holder, time.Now())
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.translator.ToSyncJobModel(jobObj), nil
+}
+
+func (s *monitoringService) CompleteSyncJob(ctx context.Context, job *model.SyncJob, result *model.ApplicationSyncResult) error {
+	if job.RunID != nil {
+		if err := s.RecordSentinelRunApplication(ctx, *job.RunID, result); err != nil {
+			return err
+		}
+	}
+
+	status := model.SyncJobStatusSucceeded
+	if result.Failed() {
+		status = model.SyncJobStatusFailed
+	}
+
+	now := time.Now()
+	if err := s.storageService.CompleteSyncJob(ctx, int(job.ID), job.ClaimedBy, status, result.ErrorMessage(), now); err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("sync job %d is no longer running for %s", job.ID, job.ClaimedBy)
+		}
+		return err
+	}
+
+	return s.storageService.FinishCompletedSentinelRuns(ctx, now)
+}
+
+func (s *monitoringService) RequeueSyncJob(ctx context.Context, job *model.SyncJob, availableAt time.Time, reason string) error {
+	now := time.Now()
+	if err := s.storageService.RequeueSyncJob(ctx, int(job.ID), now, availableAt, MaxSyncJobAttempts, reason); err != nil {
+		return err
+	}
+
+	// The job fails instead of going back to the queue once it is out of attempts, which may complete its run
+	return s.storageService.FinishCompletedSentinelRuns(ctx, now)
+}
+
+func (s *monitoringService) RequeueStaleSyncJobs(ctx context.Context, startedBefore time.Time) error {
+	now := time.Now()
+	requeued, err := s.storageService.RequeueStaleSyncJobs(ctx, startedBefore, now, MaxSyncJobAttempts)
+	if err != nil {
+		return err
+	}
+
+	if requeued > 0 {
+		s.logger.Infof("Requeued %d sync jobs abandoned by their workers", requeued)
+	}
+
+	return s.storageService.FinishCompletedSentinelRuns(ctx, now)
+}
+
+func (s *monitoringService) GetActiveSyncJobs(ctx context.Context) ([]*model.SyncJob, error) {
+	jobObjs, err := s.storageService.GetActiveSyncJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.translator.ToSyncJobModels(jobObjs), nil
+}
+
+func (s *monitoringService) UpdateSentinelSettings(ctx context.Context, sentinelSettingsUpdate *model.SentinelSettingsUpdate) error {
+	if sentinelSettingsUpdate != nil && sentinelSettingsUpdate.Interval != nil {
+		if *sentinelSettingsUpdate.Interval < s.sentinelMinIntervalSeconds || *sentinelSettingsUpdate.Interval > s.sentinelMaxIntervalSeconds {
+			return errors.NewBadRequestError(fmt.Sprintf("Interval must be between %d and %d seconds", s.sentinelMinIntervalSeconds, s.sentinelMaxIntervalSeconds))
+		}
+	}
+
+	existingSetting, err := s.storageService.GetSentinelSetting(context.Background(), SentinelSettingsName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return errors.NewNotFoundError("Sentinel settings not found")
+		}
+		return fmt.Errorf("failed to get existing sentinel setting: %v", err)
+	}
+
+	updateObj := &obj.SentinelSetting{
+		CosmosObj: obj.CosmosObj{
+			ID:        existingSetting.ID,
+			CreatedAt: existingSetting.CreatedAt,
+		},
+		Name:     existingSetting.Name,
+		Interval: existingSetting.Interval,
+		Enabled:  existingSetting.Enabled,
+	}
+
+	fmt.Printf("Current settings: %+v\n", updateObj)
+
+	if sentinelSettingsUpdate.Enabled != nil {
+		updateObj.Enabled = *sentinelSettingsUpdate.Enabled
+	}
+
+	if sentinelSettingsUpdate.Interval != nil {
+		updateObj.Interval = *sentinelSettingsUpdate.Interval
+	}
+
+	fmt.Printf("Updated settings: %+v\n", updateObj)
+
+	err = s.storageService.UpdateSentinelSetting(ctx, updateObj)
+	if err != nil {
+		return fmt.Errorf("failed to update sentinel setting: %v", err)
+	}
+
+	if s.sentinelConfigChannel != nil {
+		newSettings := model.SentinelSettings{
+			Interval: updateObj.Interval,
+			Enabled:  updateObj.Enabled,
+		}
+		s.sentinelConfigChannel <- newSettings
+	}
+
+	return nil
+}
+
+func (s *monitoringService) GetSentinelSettings(ctx context.Context) (*model.SentinelSettings, error) {
+	settingObj, err := s.storageService.GetSentinelSetting(ctx, SentinelSettingsName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError("Sentinel settings not found")
+		}
+		return nil, fmt.Errorf("failed to get sentinel setting: %v", err)
+	}
+
+	return s.translator.ToSentinelSettingsModel(settingObj), nil
+}
+
+// AcquireSentinelLeadership takes or renews the lease that allows a single replica to schedule syncs
+func (s *monitoringService) AcquireSentinelLeadership(ctx context.Context, holder string, leaseDuration time.Duration) (bool, error) {
+	now := time.Now()
+	return s.storageService.AcquireSentinelLease(ctx, SentinelLeaderLeaseName, holder, now, now.Add(leaseDuration))
+}
+
+func (s *monitoringService) ReleaseSentinelLeadership(ctx context.Context, holder string) error {
+	return s.storageService.ReleaseSentinelLease(ctx, SentinelLeaderLeaseName, holder)
+}
+
+func (s *monitoringService) GetGroupApplicationsInteractions(ctx context.Context, groupName string) (*model.ApplicationsInteractions, error) {
+	groupObj, err := s.storageService.GetGroupByName(ctx, groupName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("group %s not found", groupName))
+		}
+		return nil, fmt.Errorf("failed to get group %s: %v", groupName, err)
+	}
+
+	objDependencies, err := s.storageService.GetApplicationDependenciesFromGroup(ctx, groupObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.translator.ToApplicationsInteractionsModel(objDependencies), nil
+}
+
+func (s *monitoringService) SyncApplication(ctx context.Context, application *model.Application) *model.ApplicationSyncResult {
+	result := &model.ApplicationSyncResult{
+		Application: application,
+		StartedAt:   time.Now(),
+	}
+
+	for attempt := 1; ; attempt++ {
+		result.CommitSha, result.DependenciesSha, result.OpenAPISha = "", "", ""
+		result.DependenciesErr, result.OpenAPIErr = nil, nil
+
+		s.syncApplicationFiles(ctx, application, result)
+
+		if attempt >= s.syncPolicy.MaxAttempts || !(isTransientSyncError(result.DependenciesErr) || isTransientSyncError(result.OpenAPIErr)) {
+			break
+		}
+
+		delay := s.getRetryDelay(attempt)
+		s.logger.Warnf("Transient failure syncing application %s, retrying in %s (attempt %d of %d): %s", application.Name, delay, attempt, s.syncPolicy.MaxAttempts, result.ErrorMessage())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	result.Duration = time.Since(result.StartedAt)
+
+	// The outcome is recorded even when the sync ran out of time
+	err := s.recordApplicationSyncStatus(context.WithoutCancel(ctx), result)
+	if err != nil {
+		s.logger.Errorf("Failed to record sync status for application %s: %v", application.Name, err)
+	}
+
+	return result
+}
+
+func (s *monitoringService) syncApplicationFiles(ctx context.Context, application *model.Application, result *model.ApplicationSyncResult) {
+	updateDependencies := s.shouldUpdateDependencies(application)
+	updateOpenAPISpecification := s.shouldUpdateOpenAPISpecification(application)
+
+	paths := make([]string, 0, 2)
+	if updateDependencies {
+		paths = append(paths, application.MonitoringInformation.OpenClientPath)
+	}
+	if updateOpenAPISpecification {
+		paths = append(paths, application.MonitoringInformation.OpenApiPath)
+	}
+	if len(paths) == 0 {
+		return
+	}
+
+	snapshot, err := s.getRepositorySnapshot(ctx, application, paths...)
+	if err != nil {
+		if updateDependencies {
+			result.DependenciesErr = err
+		}
+		if updateOpenAPISpecification {
+			result.OpenAPIErr = err
+		}
+		return
+	}
+
+	result.CommitSha = snapshot.commitSha
+
+	if application.MonitoringInformation.CommitSha == snapshot.commitSha {
+		s.logger.Infof("Application %s is up to date with commit %s, skipping update", application.Name, snapshot.commitSha)
+		result.DependenciesSha = application.MonitoringInformation.DependenciesSha
+		result.OpenAPISha = application.MonitoringInformation.OpenAPISha
+		return
+	}
+
+	if updateDependencies {
+		result.DependenciesSha, result.DependenciesErr = s.updateApplicationDependencies(ctx, application, snapshot)
+	}
+	if updateOpenAPISpecification {
+		result.OpenAPISha, result.OpenAPIErr = s.updateApplicationOpenAPISpecification(ctx, application, snapshot)
+	}
+}
+
+func (s *monitoringService) recordApplicationSyncStatus(ctx context.Context, result *model.ApplicationSyncResult) error {
+	if result.Failed() {
+		permanent := isPermanentSyncError(result.DependenciesErr) || isPermanentSyncError(result.OpenAPIErr)
+		return s.storageService.RecordApplicationSyncFailure(ctx, result.Application.Name, result.StartedAt, result.ErrorMessage(), result.ErrorCategory(), permanent, s.syncPolicy.QuarantineThreshold)
+	}
+
+	return s.storageService.RecordApplicationSyncSuccess(ctx, result.Application.Name, result.StartedAt, result.CommitSha)
+}
+
+func (s *monitoringService) StartSentinelRun(ctx context.Context, trigger string) (*model.SentinelRun, error) {
+	runObj := &obj.SentinelRun{
+		Trigger:   trigger,
+		StartedAt: time.Now(),
+	}
+
+	err := s.storageService.InsertSentinelRun(ctx, runObj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sentinel run: %v", err)
+	}
+
+	return s.translator.ToSentinelRunModel(runObj), nil
+}
+
+func (s *monitoringService) RecordSentinelRunApplication(ctx context.Context, runID uint, result *model.ApplicationSyncResult) error {
+	runApplicationObj := s.translator.ToSentinelRunApplicationObj(result)
+	runApplicationObj.RunID = int(runID)
+
+	applicationObj, err := s.storageService.GetApplicationWithName(ctx, result.Application.Name)
+	if err != nil && !errorUtils.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to get application %s: %v", result.Application.Name, err)
+	}
+	if applicationObj != nil {
+		applicationID := int(applicationObj.ID)
+		runApplicationObj.ApplicationID = &applicationID
+	}
+
+	err = s.storageService.InsertSentinelRunApplication(ctx, runApplicationObj)
+	if err != nil {
+		return fmt.Errorf("failed to record sentinel run outcome for application %s: %v", result.Application.Name, err)
+	}
+
+	return nil
+}
+
+func (s *monitoringService) FinishSentinelRun(ctx context.Context, runID uint) error {
+	err := s.storageService.FinishSentinelRun(ctx, int(runID), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to finish sentinel run %d: %v", runID, err)
+	}
+
+	return nil
+}
+
+func (s *monitoringService) GetSentinelRuns(ctx context.Context, page, pageSize int) (*model.SentinelRunsPage, error) {
+	if page < 1 {
+		return nil, errors.NewBadRequestError("page must be greater than 0")
+	}
+
+	if pageSize < 1 || pageSize > MaxSentinelRunsPageSize {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("page size must be between 1 and %d", MaxSentinelRunsPageSize))
+	}
+
+	runObjs, total, err := s.storageService.GetSentinelRuns(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, errors.NewInternalServerError("failed to retrieve sentinel runs: " + err.Error())
+	}
+
+	return &model.SentinelRunsPage{
+		Runs:     s.translator.ToSentinelRunModels(runObjs),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+func (s *monitoringService) GetSentinelRun(ctx context.Context, runID uint) (*model.SentinelRun, error) {
+	runObj, err := s.storageService.GetSentinelRun(ctx, int(runID))
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("sentinel run %d not found", runID))
+		}
+		return nil, errors.NewInternalServerError("failed to retrieve sentinel run: " + err.Error())
+	}
+
+	return s.translator.ToSentinelRunModel(runObj), nil
+}
+
+func (s *monitoringService) GetGitRateLimits(_ context.Context) []*model.GitRateLimit {
+	providers := make([]string, 0, len(s.gitServices))
+	for provider := range s.gitServices {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	rateLimits := make([]*model.GitRateLimit, 0)
+	for _, provider := range providers {
+		if rateLimitedGitService, ok := s.gitServices[provider].(RateLimitedGitService); ok {
+			rateLimits = append(rateLimits, rateLimitedGitService.GetRateLimits()...)
+		}
+	}
+
+	return rateLimits
+}
+
+// getRetryDelay doubles the base delay on every attempt and picks a random delay between half and all of it, so the
+// applications failing at the same time do not retry all at once
+func (s *monitoringService) getRetryDelay(attempt int) time.Duration {
+	delay := s.syncPolicy.BaseDelay << (attempt - 1)
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// isTransientSyncError tells whether a failed sync may succeed by retrying it without any change to the application
+func isTransientSyncError(err error) bool {
+	if err == nil || isRateLimitError(err) {
+		return false
+	}
+
+	var statusErr *model.GitStatusError
+	if errorUtils.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusRequestTimeout || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	if errorUtils.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errorUtils.As(err, &netErr) {
+		return true
+	}
+
+	return model.SyncErrorCategory(err) == model.SyncErrorCategoryStorage
+}
+
+// isPermanentSyncError tells whether a failed sync will keep failing until the application or its repository changes
+func isPermanentSyncError(err error) bool {
+	if err == nil || isRateLimitError(err) || isTransientSyncError(err) {
+		return false
+	}
+
+	var statusErr *model.GitStatusError
+	if errorUtils.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusBadRequest
+	}
+
+	var programErr errors.ProgramError
+	if errorUtils.As(err, &programErr) && programErr.Type() == errors.NotFound {
+		return true
+	}
+
+	category := model.SyncErrorCategory(err)
+	return category == model.SyncErrorCategoryParse || category == model.SyncErrorCategoryValidation
+}
+
+func isRateLimitError(err error) bool {
+	var rateLimitErr *model.RateLimitError
+	return errorUtils.As(err, &rateLimitErr)
+}
+
+func (s *monitoringService) ReleaseApplicationQuarantine(ctx context.Context, applicationName string) error {
+	err := s.storageService.ReleaseApplicationQuarantine(ctx, applicationName)
+	if err != nil {
+		if errorUtils.Is(err, storage.ErrNotFound) {
+			return errors.NewNotFoundError(fmt.Sprintf("application %s not found", applicationName))
+		}
+		return errors.NewInternalServerError("failed to release application quarantine: " + err.Error())
+	}
+
+	s.logger.Infof("Application %s released from quarantine", applicationName)
+
+	return nil
 }
@@ -15,14 +15,21 @@ import (
 	"cosmos-server/pkg/storage/obj"
 	"encoding/json"
 	"errors"
+	"net/http"
 
 	//"strings"
 	"testing"
+	"time"
 
+	"github.com/oasdiff/oasdiff/checker"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+const mockedCommitSha = "0123456789abcdef0123456789abcdef01234567"
+
+var mockedSyncPolicy = SyncPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, QuarantineThreshold: 5}
+
 func TestUpdateApplicationInformation(t *testing.T) {
 	t.Run("update application information - success", updateApplicationInformationSuccess)
 	t.Run("update application information - no git information", updateApplicationInformationNoGitInformation)
@@ -46,6 +53,108 @@ func TestGetApplicationsInteractions(t *testing.T) {
 	t.Run("get applications interactions - with filter", getApplicationsInteractionsWithFilter)
 }
 
+func TestGetSentinelRuns(t *testing.T) {
+	t.Run("get sentinel runs - success", getSentinelRunsSuccess)
+	t.Run("get sentinel runs - invalid page", getSentinelRunsInvalidPage)
+	t.Run("get sentinel runs - page size too big", getSentinelRunsPageSizeTooBig)
+	t.Run("get sentinel run - not found", getSentinelRunNotFound)
+}
+
+func TestSyncApplication(t *testing.T) {
+	t.Run("sync application - git fetch failure is recorded", syncApplicationGitFetchFailure)
+	t.Run("sync application - success is recorded", syncApplicationSuccess)
+	t.Run("sync application - unchanged commit is skipped", syncApplicationUnchangedCommit)
+	t.Run("sync application - transient failure is retried", syncApplicationTransientFailureRetried)
+	t.Run("sync application - permanent failure counts towards quarantine", syncApplicationPermanentFailure)
+}
+
+func TestReleaseApplicationQuarantine(t *testing.T) {
+	t.Run("release application quarantine - success", releaseApplicationQuarantineSuccess)
+	t.Run("release application quarantine - not found", releaseApplicationQuarantineNotFound)
+}
+
+func TestAcquireSentinelLeadership(t *testing.T) {
+	t.Run("acquire sentinel leadership - lease expires after the lease duration", acquireSentinelLeadershipLeaseDuration)
+	t.Run("acquire sentinel leadership - held by another replica", acquireSentinelLeadershipHeldByAnotherReplica)
+}
+
+func TestEnqueueApplicationsSync(t *testing.T) {
+	t.Run("enqueue applications sync - jobs queued", enqueueApplicationsSyncJobsQueued)
+	t.Run("enqueue applications sync - applications already queued", enqueueApplicationsSyncAlreadyQueued)
+}
+
+func TestCompleteSyncJob(t *testing.T) {
+	t.Run("complete sync job - failed sync", completeSyncJobFailedSync)
+	t.Run("complete sync job - job lost by its worker", completeSyncJobLostJob)
+	t.Run("complete sync job - success clears the error of a requeued job", completeSyncJobRequeuedJobSucceeded)
+}
+
+func TestGitProviderSelection(t *testing.T) {
+	t.Run("git provider selection - gitlab application uses gitlab service", gitProviderSelectionGitlab)
+	t.Run("git provider selection - unsupported provider", gitProviderSelectionUnsupported)
+}
+
+func TestPreviewApplicationSync(t *testing.T) {
+	t.Run("preview application sync - dependency changes are not persisted", previewApplicationSyncDependencies)
+	t.Run("preview application sync - OpenAPI changes against the stored specification", previewApplicationSyncOpenAPIChanges)
+	t.Run("preview application sync - overridden branch cannot be read", previewApplicationSyncOverriddenBranch)
+	t.Run("preview application sync - nothing to sync", previewApplicationSyncNothingToSync)
+}
+
+func TestGetSentinelWorkers(t *testing.T) {
+	t.Run("get sentinel workers - workers and queue depth", getSentinelWorkersWithQueueDepth)
+	t.Run("get sentinel workers - sentinel not started", getSentinelWorkersSentinelNotStarted)
+}
+
+func TestOpenAPISpecificationVersions(t *testing.T) {
+	t.Run("openapi specification versions - new version is recorded with its commit", openAPISpecificationVersionsRecordedWithCommit)
+	t.Run("openapi specification versions - commit lookup failure keeps the sha", openAPISpecificationVersionsCommitLookupFailure)
+	t.Run("openapi specification versions - differences are notified once the sync context is cancelled", openAPISpecificationVersionsNotifiedAfterCancel)
+	t.Run("openapi specification versions - list versions at a time", openAPISpecificationVersionsListAtTime)
+	t.Run("openapi specification versions - page size too big", openAPISpecificationVersionsPageSizeTooBig)
+	t.Run("openapi specification versions - version not found", openAPISpecificationVersionsVersionNotFound)
+	t.Run("openapi specification versions - retention defaults to keeping everything", openAPISpecificationVersionsDefaultRetention)
+	t.Run("openapi specification versions - invalid retention", openAPISpecificationVersionsInvalidRetention)
+}
+
+func TestCompareApplicationOpenAPISpecifications(t *testing.T) {
+	t.Run("compare application openapi specifications - stored version against a git ref", compareApplicationOpenAPISpecificationsVersionAgainstGitRef)
+	t.Run("compare application openapi specifications - unsupported format", compareApplicationOpenAPISpecificationsUnsupportedFormat)
+}
+
+func TestCheckApplicationOpenAPISpecification(t *testing.T) {
+	t.Run("check application openapi specification - breaking change to a consumed operation fails", checkApplicationOpenAPISpecificationConsumedBreakingChange)
+	t.Run("check application openapi specification - consumers are matched on path templates and the servers base path", checkApplicationOpenAPISpecificationPathTemplateMatching)
+	t.Run("check application openapi specification - no stored specification passes", checkApplicationOpenAPISpecificationNoStoredSpecification)
+	t.Run("check application openapi specification - invalid candidate", checkApplicationOpenAPISpecificationInvalidCandidate)
+}
+
+func TestValidateApplicationOpenClientSpecification(t *testing.T) {
+	t.Run("validate application openclient specification - errors and warnings per dependency", validateApplicationOpenClientSpecificationDependencies)
+	t.Run("validate application openclient specification - invalid document", validateApplicationOpenClientSpecificationInvalidDocument)
+	t.Run("validate application openclient specification - empty declared field", validateApplicationOpenClientSpecificationEmptyField)
+}
+
+func TestGetContractDrifts(t *testing.T) {
+	t.Run("get contract drifts - application involved", getApplicationContractDriftsSuccess)
+	t.Run("get contract drifts - application not found", getApplicationContractDriftsNotFound)
+}
+
+func TestGetProviderOperations(t *testing.T) {
+	t.Run("get provider operations - consumers grouped by path template", getProviderOperationsGroupedByPathTemplate)
+	t.Run("get provider operations - paths matched like drift detection", getProviderOperationsMatchedLikeDriftDetection)
+	t.Run("get provider operations - invalid method", getProviderOperationsInvalidMethod)
+}
+
+func TestGetApplicationUnusedOperations(t *testing.T) {
+	t.Run("get application unused operations - deprecated operations are kept apart", getApplicationUnusedOperationsSuccess)
+	t.Run("get application unused operations - no stored specification", getApplicationUnusedOperationsNoStoredSpecification)
+}
+
+func TestRecordSentinelRunApplication(t *testing.T) {
+	t.Run("record sentinel run application - failed sync", recordSentinelRunApplicationFailedSync)
+}
+
 type mocks struct {
 	controller         *gomock.Controller
 	gitServiceMock     *mock.MockGitService
@@ -67,7 +176,7 @@ func setUp(t *testing.T) (Service, *mocks) {
 		loggerMocks:        log.NewMockLogger(controller),
 	}
 
-	service := NewMonitoringService(mocks.storageServiceMock, mocks.gitServiceMock, NewOpenApiService(), mocks.mailMock, 30, 900, mocks.encryptorMock, NewTranslator(), mocks.loggerMocks)
+	service := NewMonitoringService(mocks.storageServiceMock, map[string]GitService{model.GitProviderGithub: mocks.gitServiceMock}, NewOpenApiService(), mocks.mailMock, 30, 900, mockedSyncPolicy, mocks.encryptorMock, NewTranslator(), mocks.loggerMocks)
 
 	return service, mocks
 }
@@ -142,11 +251,15 @@ func updateApplicationInformationSuccess(t *testing.T) {
 	mockedDependenciesToDelete := make([]*obj.ApplicationDependency, 0)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
-		Return(metadata, nil)
+		GetBranchHead(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, "").
+		Return(mockedCommitSha, nil)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
+		GetFilesMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, []string{openClientPath}, "").
+		Return(map[string]*model.FileMetadata{openClientPath: metadata}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, openClientPath, "").
 		Return(fileContent, nil)
 
 	mocks.storageServiceMock.EXPECT().
@@ -161,6 +274,22 @@ func updateApplicationInformationSuccess(t *testing.T) {
 		GetApplicationDependenciesByConsumer(gomock.Any(), modelApplication.Name).
 		Return([]*obj.ApplicationDependency{}, nil)
 
+	storedDependency := getObjOpenClientSpecification()
+	storedDependency.ID = 5
+	storedDependency.Provider = providerApp
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByConsumer(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{storedDependency}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), providerApp.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: getMockedOpenAPISpecification("/users")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		UpdateContractDrifts(gomock.Any(), []int{5}, []*obj.ContractDrift{{DependencyID: 5, Path: "/users", Method: "POST"}}, gomock.Any()).
+		Return(nil)
+
 	err = service.UpdateApplicationDependencies(context.TODO(), modelApplication)
 	require.NoError(t, err)
 }
@@ -270,7 +399,11 @@ func updateApplicationInformationGetFileError(t *testing.T) {
 	gitError := errors.New("repository not found")
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
+		GetBranchHead(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, []string{openClientPath}, "").
 		Return(nil, gitError)
 
 	err := service.UpdateApplicationDependencies(context.TODO(), modelApplication)
@@ -327,11 +460,15 @@ func updateApplicationInformationInvalidJSON(t *testing.T) {
 	}
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
-		Return(metadata, nil)
+		GetBranchHead(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, []string{openClientPath}, "").
+		Return(map[string]*model.FileMetadata{openClientPath: metadata}, nil)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
+		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, openClientPath, "").
 		Return(fileContent, nil)
 
 	err := service.UpdateApplicationDependencies(context.TODO(), modelApplication)
@@ -391,11 +528,15 @@ func updateApplicationInformationInvalidSpecification(t *testing.T) {
 	}
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
-		Return(metadata, nil)
+		GetBranchHead(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, []string{openClientPath}, "").
+		Return(map[string]*model.FileMetadata{openClientPath: metadata}, nil)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
+		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, openClientPath, "").
 		Return(fileContent, nil)
 
 	err := service.UpdateApplicationDependencies(context.TODO(), modelApplication)
@@ -482,11 +623,15 @@ func updateApplicationInformationProviderNotFound(t *testing.T) {
 	mockedDependenciesToDelete := make([]*obj.ApplicationDependency, 0)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
-		Return(metadata, nil)
+		GetBranchHead(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, []string{openClientPath}, "").
+		Return(map[string]*model.FileMetadata{openClientPath: metadata}, nil)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
+		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, openClientPath, "").
 		Return(fileContent, nil)
 
 	mocks.storageServiceMock.EXPECT().
@@ -499,7 +644,8 @@ func updateApplicationInformationProviderNotFound(t *testing.T) {
 
 	mocks.storageServiceMock.EXPECT().
 		GetApplicationDependenciesByConsumer(gomock.Any(), modelApplication.Name).
-		Return([]*obj.ApplicationDependency{}, nil)
+		Return([]*obj.ApplicationDependency{}, nil).
+		Times(2)
 
 	mocks.loggerMocks.EXPECT().
 		Warnf(gomock.Any(), gomock.Any(), gomock.Any())
@@ -579,11 +725,15 @@ func updateApplicationInformationUpsertDependencyError(t *testing.T) {
 	upsertError := errors.New("database connection failed")
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
-		Return(metadata, nil)
+		GetBranchHead(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, "").
+		Return(mockedCommitSha, nil)
 
 	mocks.gitServiceMock.EXPECT().
-		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, gitInformation.RepositoryBranch, openClientPath, "").
+		GetFilesMetadata(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, []string{openClientPath}, "").
+		Return(map[string]*model.FileMetadata{openClientPath: metadata}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), gitInformation.RepositoryOwner, gitInformation.RepositoryName, mockedCommitSha, openClientPath, "").
 		Return(fileContent, nil)
 
 	mocks.storageServiceMock.EXPECT().
@@ -883,3 +1033,1281 @@ func getApplicationsInteractionsWithFilter(t *testing.T) {
 	require.Equal(t, []string{"fetch user data"}, interaction.Endpoints["/api/users"]["GET"].Reasons)
 	require.Equal(t, []string{"create user"}, interaction.Endpoints["/api/users"]["POST"].Reasons)
 }
+
+func getSentinelRunsSuccess(t *testing.T) {
+	service, mocks := setUp(t)
+
+	startedAt := time.Now().Add(-time.Minute)
+	finishedAt := time.Now()
+
+	objRuns := []*obj.SentinelRun{
+		{
+			CosmosObj:  obj.CosmosObj{ID: 7},
+			Trigger:    model.SentinelRunTriggerScheduled,
+			StartedAt:  startedAt,
+			FinishedAt: &finishedAt,
+			Applications: []*obj.SentinelRunApplication{
+				{
+					ApplicationName: "service-a",
+					Status:          model.SyncStatusFailed,
+					DurationMs:      1500,
+					Error:           "failed to get openclient.json",
+				},
+			},
+		},
+	}
+
+	mocks.storageServiceMock.EXPECT().
+		GetSentinelRuns(gomock.Any(), 20, 10).
+		Return(objRuns, int64(21), nil)
+
+	runsPage, err := service.GetSentinelRuns(context.TODO(), 3, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, runsPage.Page)
+	require.Equal(t, 10, runsPage.PageSize)
+	require.Equal(t, int64(21), runsPage.Total)
+	require.Len(t, runsPage.Runs, 1)
+	require.Equal(t, uint(7), runsPage.Runs[0].ID)
+	require.Equal(t, &finishedAt, runsPage.Runs[0].FinishedAt)
+	require.Len(t, runsPage.Runs[0].Applications, 1)
+	require.Equal(t, 1500*time.Millisecond, runsPage.Runs[0].Applications[0].Duration)
+	require.Equal(t, "failed to get openclient.json", runsPage.Runs[0].Applications[0].Error)
+}
+
+func getSentinelRunsInvalidPage(t *testing.T) {
+	service, _ := setUp(t)
+
+	_, err := service.GetSentinelRuns(context.TODO(), 0, 10)
+	require.Error(t, err)
+	require.Equal(t, "page must be greater than 0", err.Error())
+}
+
+func getSentinelRunsPageSizeTooBig(t *testing.T) {
+	service, _ := setUp(t)
+
+	_, err := service.GetSentinelRuns(context.TODO(), 1, MaxSentinelRunsPageSize+1)
+	require.Error(t, err)
+}
+
+func getSentinelRunNotFound(t *testing.T) {
+	service, mocks := setUp(t)
+
+	mocks.storageServiceMock.EXPECT().
+		GetSentinelRun(gomock.Any(), 42).
+		Return(nil, storage.ErrNotFound)
+
+	_, err := service.GetSentinelRun(context.TODO(), 42)
+	require.Error(t, err)
+	require.Equal(t, "sentinel run 42 not found", err.Error())
+}
+
+func recordSentinelRunApplicationFailedSync(t *testing.T) {
+	service, mocks := setUp(t)
+
+	applicationObj := &obj.Application{
+		CosmosObj: obj.CosmosObj{ID: 3},
+		Name:      "service-a",
+	}
+
+	result := &model.ApplicationSyncResult{
+		Application:     &model.Application{Name: "service-a"},
+		Duration:        2 * time.Second,
+		DependenciesSha: "abc123",
+		OpenAPISha:      "def456",
+		OpenAPIErr:      errors.New("failed to parse OpenAPI spec"),
+	}
+
+	applicationID := 3
+	expectedRunApplication := &obj.SentinelRunApplication{
+		RunID:           5,
+		ApplicationID:   &applicationID,
+		ApplicationName: "service-a",
+		Status:          model.SyncStatusFailed,
+		DependenciesSha: "abc123",
+		OpenAPISha:      "def456",
+		DurationMs:      2000,
+		Error:           "failed to parse OpenAPI spec",
+	}
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), "service-a").
+		Return(applicationObj, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		InsertSentinelRunApplication(gomock.Any(), expectedRunApplication).
+		Return(nil)
+
+	err := service.RecordSentinelRunApplication(context.TODO(), 5, result)
+	require.NoError(t, err)
+}
+
+func enqueueApplicationsSyncJobsQueued(t *testing.T) {
+	service, mocks := setUp(t)
+
+	applications := []*model.Application{{Name: "service-a"}, {Name: "service-b"}}
+
+	mocks.storageServiceMock.EXPECT().
+		InsertSentinelRun(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, run *obj.SentinelRun) error {
+			require.Equal(t, model.SentinelRunTriggerWebhook, run.Trigger)
+			run.ID = 7
+			return nil
+		})
+
+	runID := 7
+	mocks.storageServiceMock.EXPECT().
+		EnqueueSyncJobs(gomock.Any(), runID, model.SentinelRunTriggerWebhook, []string{"service-a", "service-b"}, gomock.Any()).
+		Return([]*obj.SyncJob{
+			{CosmosObj: obj.CosmosObj{ID: 1}, Application: &obj.Application{Name: "service-a"}, RunID: &runID, Status: model.SyncJobStatusQueued},
+			{CosmosObj: obj.CosmosObj{ID: 2}, Application: &obj.Application{Name: "service-b"}, RunID: &runID, Status: model.SyncJobStatusQueued},
+		}, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	jobs, err := service.EnqueueApplicationsSync(context.TODO(), model.SentinelRunTriggerWebhook, applications)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	require.Equal(t, "service-b", jobs[1].Application.Name)
+}
+
+func enqueueApplicationsSyncAlreadyQueued(t *testing.T) {
+	service, mocks := setUp(t)
+
+	applications := []*model.Application{{Name: "service-a"}}
+
+	mocks.storageServiceMock.EXPECT().
+		InsertSentinelRun(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, run *obj.SentinelRun) error {
+			run.ID = 8
+			return nil
+		})
+
+	previousRunID := 6
+	mocks.storageServiceMock.EXPECT().
+		EnqueueSyncJobs(gomock.Any(), 8, model.SentinelRunTriggerScheduled, []string{"service-a"}, gomock.Any()).
+		Return([]*obj.SyncJob{
+			{CosmosObj: obj.CosmosObj{ID: 4}, Application: &obj.Application{Name: "service-a"}, RunID: &previousRunID, Status: model.SyncJobStatusQueued},
+		}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		FinishSentinelRun(gomock.Any(), 8, gomock.Any()).
+		Return(nil)
+
+	jobs, err := service.EnqueueApplicationsSync(context.TODO(), model.SentinelRunTriggerScheduled, applications)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, uint(4), jobs[0].ID)
+}
+
+func completeSyncJobFailedSync(t *testing.T) {
+	service, mocks := setUp(t)
+
+	runID := uint(5)
+	job := &model.SyncJob{
+		ID:          11,
+		Application: &model.Application{Name: "service-a"},
+		RunID:       &runID,
+		Status:      model.SyncJobStatusRunning,
+		ClaimedBy:   "sentinel-1",
+	}
+
+	result := &model.ApplicationSyncResult{
+		Application: job.Application,
+		OpenAPIErr:  errors.New("failed to parse OpenAPI spec"),
+	}
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), "service-a").
+		Return(&obj.Application{CosmosObj: obj.CosmosObj{ID: 3}, Name: "service-a"}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		InsertSentinelRunApplication(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		CompleteSyncJob(gomock.Any(), 11, "sentinel-1", model.SyncJobStatusFailed, "failed to parse OpenAPI spec", gomock.Any()).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		FinishCompletedSentinelRuns(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	err := service.CompleteSyncJob(context.TODO(), job, result)
+	require.NoError(t, err)
+}
+
+func getSyncedModelApplication() *model.Application {
+	return &model.Application{
+		Name: "test-application",
+		GitInformation: &model.GitInformation{
+			Provider:         "github",
+			RepositoryOwner:  "test-owner",
+			RepositoryName:   "test-repo",
+			RepositoryBranch: "main",
+		},
+		MonitoringInformation: &model.MonitoringInformation{
+			DependenciesSha: "abc123",
+			HasOpenClient:   true,
+			OpenClientPath:  "docs/openclient.json",
+		},
+	}
+}
+
+func syncApplicationGitFetchFailure(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return("", errors.New("repository not found"))
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	mocks.storageServiceMock.EXPECT().
+		RecordApplicationSyncFailure(gomock.Any(), modelApplication.Name, gomock.Any(), gomock.Any(), model.SyncErrorCategoryGitFetch, false, mockedSyncPolicy.QuarantineThreshold).
+		DoAndReturn(func(_ context.Context, _ string, _ time.Time, syncError, _ string, _ bool, _ int) error {
+			require.True(t, strings.Contains(syncError, "repository not found"))
+			return nil
+		})
+
+	result := service.SyncApplication(context.TODO(), modelApplication)
+	require.True(t, result.Failed())
+	require.Equal(t, model.SyncErrorCategoryGitFetch, result.ErrorCategory())
+}
+
+func syncApplicationSuccess(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openclient.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openclient.json": &model.FileMetadata{SHA: "abc123"}}, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		Times(2)
+
+	mocks.storageServiceMock.EXPECT().
+		RecordApplicationSyncSuccess(gomock.Any(), modelApplication.Name, gomock.Any(), mockedCommitSha).
+		Return(nil)
+
+	result := service.SyncApplication(context.TODO(), modelApplication)
+	require.False(t, result.Failed())
+	require.Equal(t, "abc123", result.DependenciesSha)
+	require.Equal(t, mockedCommitSha, result.CommitSha)
+}
+
+func syncApplicationUnchangedCommit(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.MonitoringInformation.HasOpenApi = true
+	modelApplication.MonitoringInformation.OpenApiPath = "docs/openapi.json"
+	modelApplication.MonitoringInformation.CommitSha = mockedCommitSha
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openclient.json", "docs/openapi.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openclient.json": {SHA: "def456"}}, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any(), gomock.Any())
+
+	mocks.storageServiceMock.EXPECT().
+		RecordApplicationSyncSuccess(gomock.Any(), modelApplication.Name, gomock.Any(), mockedCommitSha).
+		Return(nil)
+
+	result := service.SyncApplication(context.TODO(), modelApplication)
+	require.False(t, result.Failed())
+	require.Equal(t, "abc123", result.DependenciesSha)
+}
+
+func gitProviderSelectionGitlab(t *testing.T) {
+	_, mocks := setUp(t)
+
+	gitlabServiceMock := mock.NewMockGitService(mocks.controller)
+	service := NewMonitoringService(mocks.storageServiceMock, map[string]GitService{model.GitProviderGithub: mocks.gitServiceMock, model.GitProviderGitlab: gitlabServiceMock}, NewOpenApiService(), mocks.mailMock, 30, 900, mockedSyncPolicy, mocks.encryptorMock, NewTranslator(), mocks.loggerMocks)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.GitInformation.Provider = "GitLab"
+
+	gitlabServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	gitlabServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openclient.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openclient.json": &model.FileMetadata{SHA: "abc123"}}, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	err := service.UpdateApplicationDependencies(context.TODO(), modelApplication)
+	require.NoError(t, err)
+}
+
+func gitProviderSelectionUnsupported(t *testing.T) {
+	service, _ := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.GitInformation.Provider = "svn"
+
+	err := service.UpdateApplicationDependencies(context.TODO(), modelApplication)
+	require.Error(t, err)
+	require.Equal(t, model.SyncErrorCategoryValidation, model.SyncErrorCategory(err))
+}
+
+func syncApplicationTransientFailureRetried(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.MonitoringInformation.CommitSha = mockedCommitSha
+
+	gomock.InOrder(
+		mocks.gitServiceMock.EXPECT().
+			GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+			Return("", model.NewGitStatusError(http.StatusBadGateway, errors.New("GitHub returned status 502"))),
+		mocks.gitServiceMock.EXPECT().
+			GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+			Return(mockedCommitSha, nil),
+	)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openclient.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openclient.json": {SHA: "abc123"}}, nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		Times(3)
+
+	mocks.loggerMocks.EXPECT().
+		Warnf(gomock.Any(), gomock.Any())
+
+	mocks.storageServiceMock.EXPECT().
+		RecordApplicationSyncSuccess(gomock.Any(), modelApplication.Name, gomock.Any(), mockedCommitSha).
+		Return(nil)
+
+	result := service.SyncApplication(context.TODO(), modelApplication)
+	require.False(t, result.Failed())
+}
+
+func syncApplicationPermanentFailure(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return("", model.NewGitStatusError(http.StatusNotFound, errors.New("GitHub returned status 404")))
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	mocks.storageServiceMock.EXPECT().
+		RecordApplicationSyncFailure(gomock.Any(), modelApplication.Name, gomock.Any(), gomock.Any(), model.SyncErrorCategoryGitFetch, true, mockedSyncPolicy.QuarantineThreshold).
+		Return(nil)
+
+	result := service.SyncApplication(context.TODO(), modelApplication)
+	require.True(t, result.Failed())
+}
+
+func releaseApplicationQuarantineSuccess(t *testing.T) {
+	service, mocks := setUp(t)
+
+	mocks.storageServiceMock.EXPECT().
+		ReleaseApplicationQuarantine(gomock.Any(), "test-application").
+		Return(nil)
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	err := service.ReleaseApplicationQuarantine(context.TODO(), "test-application")
+	require.NoError(t, err)
+}
+
+func acquireSentinelLeadershipLeaseDuration(t *testing.T) {
+	service, mocks := setUp(t)
+
+	mocks.storageServiceMock.EXPECT().
+		AcquireSentinelLease(gomock.Any(), SentinelLeaderLeaseName, "replica-1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, now, expiresAt time.Time) (bool, error) {
+			require.Equal(t, 30*time.Second, expiresAt.Sub(now))
+			return true, nil
+		})
+
+	leader, err := service.AcquireSentinelLeadership(context.TODO(), "replica-1", 30*time.Second)
+	require.NoError(t, err)
+	require.True(t, leader)
+}
+
+func acquireSentinelLeadershipHeldByAnotherReplica(t *testing.T) {
+	service, mocks := setUp(t)
+
+	mocks.storageServiceMock.EXPECT().
+		AcquireSentinelLease(gomock.Any(), SentinelLeaderLeaseName, "replica-2", gomock.Any(), gomock.Any()).
+		Return(false, nil)
+
+	leader, err := service.AcquireSentinelLeadership(context.TODO(), "replica-2", 30*time.Second)
+	require.NoError(t, err)
+	require.False(t, leader)
+}
+
+func releaseApplicationQuarantineNotFound(t *testing.T) {
+	service, mocks := setUp(t)
+
+	mocks.storageServiceMock.EXPECT().
+		ReleaseApplicationQuarantine(gomock.Any(), "unknown-application").
+		Return(storage.ErrNotFound)
+
+	err := service.ReleaseApplicationQuarantine(context.TODO(), "unknown-application")
+	require.Error(t, err)
+	require.Equal(t, "application unknown-application not found", err.Error())
+}
+
+func previewApplicationSyncDependencies(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.MonitoringInformation.CommitSha = mockedCommitSha
+
+	jsonContent, err := json.Marshal(getMockedOpenClientSpecification())
+	require.NoError(t, err)
+
+	metadata := &model.FileMetadata{Path: "docs/openclient.json", SHA: "abc123"}
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openclient.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openclient.json": metadata}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "docs/openclient.json", "").
+		Return(&model.FileContent{Metadata: *metadata, Content: string(jsonContent)}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), "service-a").
+		Return(&obj.Application{CosmosObj: obj.CosmosObj{ID: 1}, Name: "service-a"}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByConsumer(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{{
+			Consumer: &obj.Application{Name: modelApplication.Name},
+			Provider: &obj.Application{Name: "service-b"},
+		}}, nil)
+
+	preview, err := service.PreviewApplicationSync(context.TODO(), modelApplication, nil)
+	require.NoError(t, err)
+	require.Equal(t, mockedCommitSha, preview.CommitSha)
+	require.Nil(t, preview.OpenAPI)
+	require.NoError(t, preview.Dependencies.Err)
+	require.Equal(t, "abc123", preview.Dependencies.Sha)
+	require.Len(t, preview.Dependencies.DependenciesToUpsert, 1)
+	require.Equal(t, "service-a", preview.Dependencies.DependenciesToUpsert[0].Provider.Name)
+	require.Empty(t, preview.Dependencies.PendingDependencies)
+	require.Len(t, preview.Dependencies.DependenciesToDelete, 1)
+	require.Equal(t, "service-b", preview.Dependencies.DependenciesToDelete[0].Provider.Name)
+}
+
+func getMockedOpenAPISpecification(paths ...string) string {
+	pathsContent := make([]string, 0, len(paths))
+	for _, path := range paths {
+		pathsContent = append(pathsContent, `"`+path+`": {"get": {"responses": {"200": {"description": "OK"}}}}`)
+	}
+
+	return `{"openapi": "3.0.0", "info": {"title": "test", "version": "1.0.0"}, "paths": {` + strings.Join(pathsContent, ", ") + `}}`
+}
+
+func previewApplicationSyncOpenAPIChanges(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.MonitoringInformation.HasOpenClient = false
+	modelApplication.MonitoringInformation.HasOpenApi = true
+	modelApplication.MonitoringInformation.OpenApiPath = "docs/openapi.json"
+
+	metadata := &model.FileMetadata{Path: "docs/openapi.json", SHA: "def456"}
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openapi.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openapi.json": metadata}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "docs/openapi.json", "").
+		Return(&model.FileContent{Metadata: *metadata, Content: getMockedOpenAPISpecification("/users")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: getMockedOpenAPISpecification("/users", "/orders")}, nil)
+
+	preview, err := service.PreviewApplicationSync(context.TODO(), modelApplication, nil)
+	require.NoError(t, err)
+	require.Nil(t, preview.Dependencies)
+	require.NoError(t, preview.OpenAPI.Err)
+	require.True(t, preview.OpenAPI.HasStoredSpecification)
+	require.Len(t, preview.OpenAPI.Changes, 1)
+	require.Equal(t, "/orders", preview.OpenAPI.Changes[0].Path)
+	require.Equal(t, "GET", preview.OpenAPI.Changes[0].Operation)
+	require.Equal(t, "error", preview.OpenAPI.Changes[0].Level)
+}
+
+func previewApplicationSyncOverriddenBranch(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+
+	changes := &model.ApplicationUpdate{
+		GitInformation: &model.GitInformation{
+			Provider:         "github",
+			RepositoryOwner:  "test-owner",
+			RepositoryName:   "test-repo",
+			RepositoryBranch: "feature",
+		},
+	}
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "feature", "").
+		Return("", errors.New("branch not found"))
+
+	preview, err := service.PreviewApplicationSync(context.TODO(), modelApplication, changes)
+	require.NoError(t, err)
+	require.Equal(t, "feature", preview.Application.GitInformation.RepositoryBranch)
+	require.Equal(t, "main", modelApplication.GitInformation.RepositoryBranch)
+	require.Error(t, preview.Dependencies.Err)
+	require.Equal(t, model.SyncErrorCategoryGitFetch, model.SyncErrorCategory(preview.Dependencies.Err))
+}
+
+func previewApplicationSyncNothingToSync(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getSyncedModelApplication()
+	modelApplication.GitInformation = nil
+
+	mocks.loggerMocks.EXPECT().
+		Infof(gomock.Any(), gomock.Any()).
+		Times(2)
+
+	_, err := service.PreviewApplicationSync(context.TODO(), modelApplication, nil)
+	require.Error(t, err)
+}
+
+func getSentinelWorkersWithQueueDepth(t *testing.T) {
+	service, mocks := setUp(t)
+
+	startedAt := time.Now()
+	deadline := startedAt.Add(5 * time.Minute)
+	workers := []*model.SentinelWorker{
+		{ID: 0, Job: &model.SyncJob{ID: 3, Application: &model.Application{Name: "test-application"}}, StartedAt: &startedAt, Deadline: &deadline},
+		{ID: 1},
+	}
+	service.StoreSentinelWorkersProvider(func() []*model.SentinelWorker {
+		return workers
+	})
+
+	mocks.storageServiceMock.EXPECT().
+		CountQueuedSyncJobs(gomock.Any()).
+		Return(int64(7), nil)
+
+	sentinelWorkers, err := service.GetSentinelWorkers(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, int64(7), sentinelWorkers.QueueDepth)
+	require.Equal(t, workers, sentinelWorkers.Workers)
+	require.Equal(t, model.SentinelWorkerStatusRunning, sentinelWorkers.Workers[0].Status())
+	require.Equal(t, model.SentinelWorkerStatusIdle, sentinelWorkers.Workers[1].Status())
+}
+
+func getSentinelWorkersSentinelNotStarted(t *testing.T) {
+	service, mocks := setUp(t)
+
+	mocks.storageServiceMock.EXPECT().
+		CountQueuedSyncJobs(gomock.Any()).
+		Return(int64(0), nil)
+
+	sentinelWorkers, err := service.GetSentinelWorkers(context.TODO())
+	require.NoError(t, err)
+	require.Empty(t, sentinelWorkers.Workers)
+}
+
+func getOpenAPIModelApplication() *model.Application {
+	modelApplication := getSyncedModelApplication()
+	modelApplication.MonitoringInformation.HasOpenClient = false
+	modelApplication.MonitoringInformation.HasOpenApi = true
+	modelApplication.MonitoringInformation.OpenApiPath = "docs/openapi.json"
+
+	return modelApplication
+}
+
+func expectOpenAPISpecificationFetch(mocks *mocks, modelApplication *model.Application, metadata *model.FileMetadata) {
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openapi.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openapi.json": metadata}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "docs/openapi.json", "").
+		Return(&model.FileContent{Metadata: *metadata, Content: getMockedOpenAPISpecification("/users")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(nil, storage.ErrNotFound)
+}
+
+func openAPISpecificationVersionsRecordedWithCommit(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	metadata := &model.FileMetadata{Path: "docs/openapi.json", SHA: "def456"}
+	committedAt := time.Now().Add(-time.Hour)
+
+	expectOpenAPISpecificationFetch(mocks, modelApplication, metadata)
+
+	mocks.gitServiceMock.EXPECT().
+		GetCommit(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "").
+		Return(&model.GitCommit{Sha: mockedCommitSha, Message: "Add users endpoint", AuthorName: "test", AuthorEmail: "test@test.com", Date: &committedAt}, nil)
+
+	var storedVersion *obj.ApplicationOpenAPIVersion
+	mocks.storageServiceMock.EXPECT().
+		UpsertOpenAPISpecification(gomock.Any(), modelApplication.Name, gomock.Any(), "def456", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, openAPISpec *obj.ApplicationOpenAPI, _ string, version *obj.ApplicationOpenAPIVersion) error {
+			require.Equal(t, openAPISpec.OpenAPI, version.OpenAPI)
+			storedVersion = version
+			return nil
+		})
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{}, nil)
+
+	err := service.UpdateApplicationOpenAPISpecification(context.TODO(), modelApplication)
+	require.NoError(t, err)
+	require.NotNil(t, storedVersion)
+	require.Equal(t, "def456", storedVersion.OpenAPISha)
+	require.Equal(t, mockedCommitSha, storedVersion.CommitSha)
+	require.Equal(t, "Add users endpoint", storedVersion.CommitMessage)
+	require.Equal(t, "test@test.com", storedVersion.CommitAuthorEmail)
+	require.Equal(t, &committedAt, storedVersion.CommittedAt)
+	require.False(t, storedVersion.IngestedAt.IsZero())
+}
+
+func openAPISpecificationVersionsCommitLookupFailure(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	metadata := &model.FileMetadata{Path: "docs/openapi.json", SHA: "def456"}
+
+	expectOpenAPISpecificationFetch(mocks, modelApplication, metadata)
+
+	mocks.gitServiceMock.EXPECT().
+		GetCommit(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "").
+		Return(nil, errors.New("commit not found"))
+
+	mocks.loggerMocks.EXPECT().
+		Warnf(gomock.Any(), gomock.Any())
+
+	mocks.storageServiceMock.EXPECT().
+		UpsertOpenAPISpecification(gomock.Any(), modelApplication.Name, gomock.Any(), "def456", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ *obj.ApplicationOpenAPI, _ string, version *obj.ApplicationOpenAPIVersion) error {
+			require.Equal(t, mockedCommitSha, version.CommitSha)
+			require.Empty(t, version.CommitMessage)
+			require.Nil(t, version.CommittedAt)
+			return nil
+		})
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{}, nil)
+
+	err := service.UpdateApplicationOpenAPISpecification(context.TODO(), modelApplication)
+	require.NoError(t, err)
+}
+
+func openAPISpecificationVersionsNotifiedAfterCancel(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	metadata := &model.FileMetadata{Path: "docs/openapi.json", SHA: "def456"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mocks.gitServiceMock.EXPECT().
+		GetBranchHead(gomock.Any(), "test-owner", "test-repo", "main", "").
+		Return(mockedCommitSha, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFilesMetadata(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, []string{"docs/openapi.json"}, "").
+		Return(map[string]*model.FileMetadata{"docs/openapi.json": metadata}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "docs/openapi.json", "").
+		Return(&model.FileContent{Metadata: *metadata, Content: getMockedOpenAPISpecification("/users")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: getMockedOpenAPISpecification("/users", "/orders")}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetCommit(gomock.Any(), "test-owner", "test-repo", mockedCommitSha, "").
+		Return(&model.GitCommit{Sha: mockedCommitSha}, nil)
+
+	// The sync context is cancelled before the differences are notified, as the sentinel does once the sync returns
+	mocks.storageServiceMock.EXPECT().
+		UpsertOpenAPISpecification(gomock.Any(), modelApplication.Name, gomock.Any(), "def456", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ *obj.ApplicationOpenAPI, _ string, _ *obj.ApplicationOpenAPIVersion) error {
+			cancel()
+			return nil
+		})
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{}, nil).
+		Times(2)
+
+	notified := make(chan error, 1)
+	mocks.mailMock.EXPECT().
+		SendOpenAPIDifferencesNotification(gomock.Any(), modelApplication, gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(notifyCtx context.Context, _ *model.Application, _ *model.OperationMatcher, _ []*model.AppEndpointDependencies, changes checker.Changes) {
+			require.Len(t, changes, 1)
+			notified <- notifyCtx.Err()
+		})
+
+	err := service.UpdateApplicationOpenAPISpecification(ctx, modelApplication)
+	require.NoError(t, err)
+
+	select {
+	case err := <-notified:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("differences were not notified")
+	}
+}
+
+func openAPISpecificationVersionsListAtTime(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	at := time.Now().Add(-7 * 24 * time.Hour)
+	ingestedAt := at.Add(-time.Hour)
+
+	objVersions := []*obj.ApplicationOpenAPIVersion{
+		{
+			CosmosObj:  obj.CosmosObj{ID: 4},
+			OpenAPISha: "def456",
+			CommitSha:  mockedCommitSha,
+			IngestedAt: ingestedAt,
+		},
+		{
+			CosmosObj:  obj.CosmosObj{ID: 1},
+			OpenAPISha: "abc123",
+			IngestedAt: ingestedAt.Add(-time.Hour),
+		},
+	}
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationVersions(gomock.Any(), modelApplication.Name, &at, 0, 20).
+		Return(objVersions, int64(2), nil)
+
+	versionsPage, err := service.GetApplicationOpenAPISpecificationVersions(context.TODO(), modelApplication, &at, 1, 20)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), versionsPage.Total)
+	require.Len(t, versionsPage.Versions, 2)
+	require.Equal(t, uint(4), versionsPage.Versions[0].ID)
+	require.Equal(t, mockedCommitSha, versionsPage.Versions[0].Commit.Sha)
+	require.Nil(t, versionsPage.Versions[0].OpenAPISpec)
+	require.Nil(t, versionsPage.Versions[1].Commit)
+}
+
+func openAPISpecificationVersionsPageSizeTooBig(t *testing.T) {
+	service, _ := setUp(t)
+
+	_, err := service.GetApplicationOpenAPISpecificationVersions(context.TODO(), getOpenAPIModelApplication(), nil, 1, MaxOpenAPIVersionsPageSize+1)
+	require.Error(t, err)
+}
+
+func openAPISpecificationVersionsVersionNotFound(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationVersion(gomock.Any(), modelApplication.Name, 9).
+		Return(nil, storage.ErrNotFound)
+
+	_, err := service.GetApplicationOpenAPISpecificationVersion(context.TODO(), modelApplication, 9)
+	require.Error(t, err)
+	require.Equal(t, "OpenAPI specification version 9 not found for application test-application", err.Error())
+}
+
+func openAPISpecificationVersionsDefaultRetention(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationRetention(gomock.Any(), modelApplication.Name).
+		Return(nil, storage.ErrNotFound)
+
+	retention, err := service.GetApplicationOpenAPIRetention(context.TODO(), modelApplication)
+	require.NoError(t, err)
+	require.Nil(t, retention.MaxVersions)
+	require.Nil(t, retention.MaxAgeDays)
+}
+
+func openAPISpecificationVersionsInvalidRetention(t *testing.T) {
+	service, _ := setUp(t)
+
+	maxVersions := 0
+
+	err := service.UpdateApplicationOpenAPIRetention(context.TODO(), getOpenAPIModelApplication(), &model.OpenAPIRetention{MaxVersions: &maxVersions})
+	require.Error(t, err)
+	require.Equal(t, "the maximum number of versions must be greater than 0", err.Error())
+}
+
+func compareApplicationOpenAPISpecificationsVersionAgainstGitRef(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	baseVersionID := uint(4)
+	base := &model.OpenAPISpecificationReference{VersionID: &baseVersionID}
+	revision := &model.OpenAPISpecificationReference{GitRef: "feature/remove-orders"}
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationVersion(gomock.Any(), modelApplication.Name, 4).
+		Return(&obj.ApplicationOpenAPIVersion{CosmosObj: obj.CosmosObj{ID: 4}, OpenAPI: getMockedOpenAPISpecification("/users", "/orders", "/invoices")}, nil)
+
+	mocks.gitServiceMock.EXPECT().
+		GetFileWithContent(gomock.Any(), "test-owner", "test-repo", "feature/remove-orders", "docs/openapi.json", "").
+		Return(&model.FileContent{Content: getMockedOpenAPISpecification("/users")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{
+			{
+				Consumer:  &obj.Application{Name: "service-b"},
+				Endpoints: obj.Endpoints{"/orders": obj.EndpointMethods{"get": obj.EndpointDetails{}}},
+			},
+		}, nil)
+
+	diff, err := service.CompareApplicationOpenAPISpecifications(context.TODO(), modelApplication, base, revision, model.OpenAPIDiffFormatMarkdown)
+	require.NoError(t, err)
+	require.Len(t, diff.Changes, 2)
+
+	affectedConsumers := make(map[string][]string, len(diff.Changes))
+	for _, change := range diff.Changes {
+		require.Equal(t, "error", change.Level)
+		affectedConsumers[change.Path] = change.AffectedConsumers
+	}
+	require.Equal(t, []string{"service-b"}, affectedConsumers["/orders"])
+	require.Empty(t, affectedConsumers["/invoices"])
+	require.Contains(t, diff.Rendered, "/invoices")
+}
+
+func compareApplicationOpenAPISpecificationsUnsupportedFormat(t *testing.T) {
+	service, _ := setUp(t)
+
+	revision := &model.OpenAPISpecificationReference{GitRef: "main"}
+
+	_, err := service.CompareApplicationOpenAPISpecifications(context.TODO(), getOpenAPIModelApplication(), revision, revision, "pdf")
+	require.Error(t, err)
+	require.Equal(t, "format must be html or markdown", err.Error())
+}
+
+func checkApplicationOpenAPISpecificationConsumedBreakingChange(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: getMockedOpenAPISpecification("/users", "/orders", "/invoices")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{
+			{
+				Consumer:  &obj.Application{Name: "service-b"},
+				Endpoints: obj.Endpoints{"/orders": obj.EndpointMethods{"get": obj.EndpointDetails{}}},
+			},
+		}, nil)
+
+	check, err := service.CheckApplicationOpenAPISpecification(context.TODO(), modelApplication, getMockedOpenAPISpecification("/users"), "")
+	require.NoError(t, err)
+	require.False(t, check.Passed)
+	require.True(t, check.HasStoredSpecification)
+	require.Equal(t, model.OpenAPIChangeLevelError, check.FailOn)
+	require.Len(t, check.Changes, 1)
+	require.Equal(t, "/orders", check.Changes[0].Path)
+	require.Equal(t, []string{"service-b"}, check.Changes[0].AffectedConsumers)
+	require.Equal(t, 1, check.IgnoredChangesCount)
+}
+
+func checkApplicationOpenAPISpecificationNoStoredSpecification(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(nil, storage.ErrNotFound)
+
+	check, err := service.CheckApplicationOpenAPISpecification(context.TODO(), modelApplication, getMockedOpenAPISpecification("/users"), model.OpenAPIChangeLevelWarning)
+	require.NoError(t, err)
+	require.True(t, check.Passed)
+	require.False(t, check.HasStoredSpecification)
+	require.Empty(t, check.Changes)
+}
+
+func checkApplicationOpenAPISpecificationInvalidCandidate(t *testing.T) {
+	service, _ := setUp(t)
+
+	_, err := service.CheckApplicationOpenAPISpecification(context.TODO(), getOpenAPIModelApplication(), `{"info": {"title": "test"}}`, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse the candidate OpenAPI spec of application test-application")
+}
+
+func validateApplicationOpenClientSpecificationDependencies(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	candidateSpec := `{"dependencies": {
+		"service-b": {"reasons": ["orders"], "endpoints": {"/users/{userId}": {"get": {}}, "/orders": {"get": {}, "post": {}}, "/invoices": {"get": {}}}},
+		"service-c": {"reasons": ["billing"], "endpoints": {"/bills": {"get": {}}}}
+	}}`
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), "service-b").
+		Return(&obj.Application{Name: "service-b"}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), "service-b").
+		Return(&obj.ApplicationOpenAPI{OpenAPI: getMockedOpenAPISpecification("/users/{id}", "/orders")}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationWithName(gomock.Any(), "service-c").
+		Return(nil, storage.ErrNotFound)
+
+	openClientValidation, err := service.ValidateApplicationOpenClientSpecification(context.TODO(), modelApplication, candidateSpec)
+	require.NoError(t, err)
+	require.False(t, openClientValidation.Valid)
+	require.Empty(t, openClientValidation.Errors)
+	require.Equal(t, []*model.OpenClientDependencyValidation{
+		{
+			Name: "service-b",
+			Errors: []string{
+				"path /invoices is not declared in the OpenAPI specification of application service-b",
+				"method POST of path /orders is not declared in the OpenAPI specification of application service-b",
+			},
+			Warnings: []string{},
+		},
+		{
+			Name:     "service-c",
+			Pending:  true,
+			Errors:   []string{},
+			Warnings: []string{"application service-c is not registered, the dependency stays pending until it is"},
+		},
+	}, openClientValidation.Dependencies)
+}
+
+func validateApplicationOpenClientSpecificationInvalidDocument(t *testing.T) {
+	service, _ := setUp(t)
+
+	openClientValidation, err := service.ValidateApplicationOpenClientSpecification(context.TODO(), getOpenAPIModelApplication(), `{"dependencies": {"service-b": {"endpoints": {"/orders": {"FETCH": {}}}}}}`)
+	require.NoError(t, err)
+	require.False(t, openClientValidation.Valid)
+	require.Len(t, openClientValidation.Errors, 1)
+	require.Contains(t, openClientValidation.Errors[0], "invalid HTTP method 'FETCH' for dependency service-b")
+	require.Empty(t, openClientValidation.Dependencies)
+}
+
+func validateApplicationOpenClientSpecificationEmptyField(t *testing.T) {
+	service, _ := setUp(t)
+
+	openClientValidation, err := service.ValidateApplicationOpenClientSpecification(context.TODO(), getOpenAPIModelApplication(), `{"dependencies": {"service-b": {"endpoints": {"/orders": {"GET": {"parameters": ["status"], "fields": ["items.id", " . "]}}}}}}`)
+	require.NoError(t, err)
+	require.False(t, openClientValidation.Valid)
+	require.Len(t, openClientValidation.Errors, 1)
+	require.Contains(t, openClientValidation.Errors[0], "field cannot be empty for endpoint GET /orders of dependency service-b")
+	require.Empty(t, openClientValidation.Dependencies)
+}
+
+func getApplicationContractDriftsSuccess(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	firstDetectedAt := time.Now().Add(-24 * time.Hour)
+
+	mocks.storageServiceMock.EXPECT().
+		GetContractDriftsWithApplicationInvolved(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ContractDrift{
+			{
+				Dependency: &obj.ApplicationDependency{
+					Consumer: &obj.Application{Name: "service-b"},
+					Provider: &obj.Application{Name: modelApplication.Name},
+				},
+				Path:            "/orders",
+				Method:          "DELETE",
+				FirstDetectedAt: firstDetectedAt,
+			},
+		}, nil)
+
+	drifts, err := service.GetApplicationContractDrifts(context.TODO(), modelApplication)
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	require.Equal(t, "service-b", drifts[0].Consumer.Name)
+	require.Equal(t, modelApplication.Name, drifts[0].Provider.Name)
+	require.Equal(t, "/orders", drifts[0].Path)
+	require.Equal(t, "DELETE", drifts[0].Method)
+	require.Equal(t, firstDetectedAt, drifts[0].FirstDetectedAt)
+}
+
+func getApplicationContractDriftsNotFound(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+
+	mocks.storageServiceMock.EXPECT().
+		GetContractDriftsWithApplicationInvolved(gomock.Any(), modelApplication.Name).
+		Return(nil, storage.ErrNotFound)
+
+	_, err := service.GetApplicationContractDrifts(context.TODO(), modelApplication)
+	require.Error(t, err)
+	require.Equal(t, "application test-application not found", err.Error())
+}
+
+func getProviderOperationsGroupedByPathTemplate(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	filter := model.EndpointConsumerFilter{Path: "/orders/42"}
+
+	mocks.storageServiceMock.EXPECT().
+		GetEndpointConsumers(gomock.Any(), modelApplication.Name, "").
+		Return([]*obj.EndpointConsumer{
+			{ConsumerName: "billing-service", Path: "/orders/{id}", Method: "DELETE", Reasons: []string{"cancel unpaid orders"}},
+			{ConsumerName: "support-service", Path: "/orders/{orderId}/", Method: "DELETE"},
+			{ConsumerName: "support-service", Path: "/orders/{orderId}", Method: "GET", Reasons: []string{"show order"}},
+			{ConsumerName: "support-service", Path: "/orders", Method: "GET"},
+		}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: getMockedOpenAPISpecification("/orders", "/orders/{id}")}, nil)
+
+	operations, err := service.GetProviderOperations(context.TODO(), modelApplication, filter)
+	require.NoError(t, err)
+	require.Equal(t, []*model.ProviderOperation{
+		{
+			Method: "DELETE",
+			Path:   "/orders/{id}",
+			Consumers: []*model.OperationConsumer{
+				{Name: "billing-service", Reasons: []string{"cancel unpaid orders"}},
+				{Name: "support-service"},
+			},
+		},
+		{
+			Method: "GET",
+			Path:   "/orders/{orderId}",
+			Consumers: []*model.OperationConsumer{
+				{Name: "support-service", Reasons: []string{"show order"}},
+			},
+		},
+	}, operations)
+}
+
+func getProviderOperationsMatchedLikeDriftDetection(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	filter := model.EndpointConsumerFilter{Method: "GET", Path: "/Orders/42"}
+	storedSpec := `{"openapi": "3.0.0", "info": {"title": "test", "version": "1.0.0"}, "servers": [{"url": "https://orders.example.com/api/v1"}], "paths": {
+		"/orders/{id}": {"get": {"responses": {"200": {"description": "OK"}}}}
+	}}`
+
+	mocks.storageServiceMock.EXPECT().
+		GetEndpointConsumers(gomock.Any(), modelApplication.Name, "GET").
+		Return([]*obj.EndpointConsumer{
+			{ConsumerName: "billing-service", Path: "/api/v1/orders/{orderId}", Method: "GET"},
+			{ConsumerName: "shipping-service", Path: "/api/v1/shipments/{id}", Method: "GET"},
+			{ConsumerName: "support-service", Path: "/ORDERS/{id}", Method: "GET"},
+		}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: storedSpec}, nil)
+
+	operations, err := service.GetProviderOperations(context.TODO(), modelApplication, filter)
+	require.NoError(t, err)
+	require.Len(t, operations, 2)
+	require.Equal(t, "/api/v1/orders/{orderId}", operations[0].Path)
+	require.Equal(t, "billing-service", operations[0].Consumers[0].Name)
+	require.Equal(t, "/ORDERS/{id}", operations[1].Path)
+	require.Equal(t, "support-service", operations[1].Consumers[0].Name)
+}
+
+func getProviderOperationsInvalidMethod(t *testing.T) {
+	service, _ := setUp(t)
+
+	_, err := service.GetProviderOperations(context.TODO(), getOpenAPIModelApplication(), model.EndpointConsumerFilter{Method: "FETCH"})
+	require.Error(t, err)
+	require.Equal(t, "invalid HTTP method FETCH", err.Error())
+}
+
+func getApplicationUnusedOperationsSuccess(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	openAPISpec := `{"openapi": "3.0.0", "info": {"title": "test", "version": "1.0.0"}, "paths": {
+		"/orders": {"get": {"responses": {"200": {"description": "OK"}}}, "post": {"operationId": "createOrder", "responses": {"201": {"description": "Created"}}}},
+		"/orders/{id}": {"get": {"responses": {"200": {"description": "OK"}}}, "delete": {"summary": "Cancel an order", "deprecated": true, "responses": {"204": {"description": "No Content"}}}}
+	}}`
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: openAPISpec}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{
+			{
+				Consumer:  &obj.Application{Name: "service-b"},
+				Endpoints: obj.Endpoints{"/orders": obj.EndpointMethods{"get": obj.EndpointDetails{}}, "/orders/{orderId}/": obj.EndpointMethods{"GET": obj.EndpointDetails{}}},
+			},
+		}, nil)
+
+	report, err := service.GetApplicationUnusedOperations(context.TODO(), modelApplication)
+	require.NoError(t, err)
+	require.True(t, report.HasStoredSpecification)
+	require.Equal(t, []*model.UnusedOperation{
+		{Method: "POST", Path: "/orders", OperationID: "createOrder"},
+	}, report.UnusedOperations)
+	require.Equal(t, []*model.UnusedOperation{
+		{Method: "DELETE", Path: "/orders/{id}", Summary: "Cancel an order", Deprecated: true},
+	}, report.DeprecatedUnusedOperations)
+}
+
+func getApplicationUnusedOperationsNoStoredSpecification(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(nil, storage.ErrNotFound)
+
+	report, err := service.GetApplicationUnusedOperations(context.TODO(), modelApplication)
+	require.NoError(t, err)
+	require.False(t, report.HasStoredSpecification)
+	require.Empty(t, report.UnusedOperations)
+	require.Empty(t, report.DeprecatedUnusedOperations)
+}
+
+func checkApplicationOpenAPISpecificationPathTemplateMatching(t *testing.T) {
+	service, mocks := setUp(t)
+
+	modelApplication := getOpenAPIModelApplication()
+	storedSpec := `{"openapi": "3.0.0", "info": {"title": "test", "version": "1.0.0"}, "servers": [{"url": "https://users.example.com/api/v1"}], "paths": {
+		"/users/{userId}": {"get": {"responses": {"200": {"description": "OK"}}}},
+		"/orders": {"get": {"responses": {"200": {"description": "OK"}}}}
+	}}`
+
+	mocks.storageServiceMock.EXPECT().
+		GetOpenAPISpecificationByApplicationName(gomock.Any(), modelApplication.Name).
+		Return(&obj.ApplicationOpenAPI{OpenAPI: storedSpec}, nil)
+
+	mocks.storageServiceMock.EXPECT().
+		GetApplicationDependenciesByProvider(gomock.Any(), modelApplication.Name).
+		Return([]*obj.ApplicationDependency{
+			{
+				Consumer:  &obj.Application{Name: "service-b"},
+				Endpoints: obj.Endpoints{"/users/{id}/": obj.EndpointMethods{"GET": obj.EndpointDetails{}}},
+			},
+			{
+				Consumer:  &obj.Application{Name: "service-c"},
+				Endpoints: obj.Endpoints{"/api/v1/users/{uid}": obj.EndpointMethods{"get": obj.EndpointDetails{}}},
+			},
+		}, nil)
+
+	check, err := service.CheckApplicationOpenAPISpecification(context.TODO(), modelApplication, getMockedOpenAPISpecification("/orders"), "")
+	require.NoError(t, err)
+	require.False(t, check.Passed)
+	require.Len(t, check.Changes, 1)
+	require.Equal(t, "/users/{userId}", check.Changes[0].Path)
+	require.Equal(t, []string{"service-b", "service-c"}, check.Changes[0].AffectedConsumers)
+}
+
+func completeSyncJobLostJob(t *testing.T) {
+	service, mocks := setUp(t)
+
+	job := &model.SyncJob{
+		ID:          11,
+		Application: &model.Application{Name: "service-a"},
+		Status:      model.SyncJobStatusRunning,
+		ClaimedBy:   "sentinel-1",
+	}
+
+	result := &model.ApplicationSyncResult{Application: job.Application}
+
+	// The job was requeued and claimed by another worker meanwhile
+	mocks.storageServiceMock.EXPECT().
+		CompleteSyncJob(gomock.Any(), 11, "sentinel-1", model.SyncJobStatusSucceeded, "", gomock.Any()).
+		Return(storage.ErrNotFound)
+
+	err := service.CompleteSyncJob(context.TODO(), job, result)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sync job 11 is no longer running for sentinel-1")
+}
+
+func completeSyncJobRequeuedJobSucceeded(t *testing.T) {
+	service, mocks := setUp(t)
+
+	// The previous attempt was interrupted and requeued with its error
+	job := &model.SyncJob{
+		ID:          11,
+		Application: &model.Application{Name: "service-a"},
+		Status:      model.SyncJobStatusRunning,
+		Attempts:    2,
+		ClaimedBy:   "sentinel-1",
+		Error:       "sync was interrupted by a shutdown",
+	}
+
+	result := &model.ApplicationSyncResult{Application: job.Application}
+
+	mocks.storageServiceMock.EXPECT().
+		CompleteSyncJob(gomock.Any(), 11, "sentinel-1", model.SyncJobStatusSucceeded, "", gomock.Any()).
+		Return(nil)
+
+	mocks.storageServiceMock.EXPECT().
+		FinishCompletedSentinelRuns(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	err := service.CompleteSyncJob(context.TODO(), job, result)
+	require.NoError(t, err)
+}
@@ -3,8 +3,10 @@ package monitoring
 import (
 	"cosmos-server/pkg/model"
 	"cosmos-server/pkg/storage/obj"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/oasdiff/checker"
 )
 
 type Translator interface {
@@ -15,12 +17,29 @@ type Translator interface {
 
 	ToPendingApplicationDependencyObj(modelPendingDependency *model.PendingApplicationDependency) *obj.PendingApplicationDependency
 
-	ToApplicationOpenApiObj(openApiSpec *openapi3.T) (*obj.ApplicationOpenAPI, error)
+	ToApplicationOpenApiObj(openApiSpec *openapi3.T, openAPIVersion string) (*obj.ApplicationOpenAPI, error)
 	ToApplicationOpenApiModel(objOpenApi *obj.ApplicationOpenAPI) (*model.ApplicationOpenAPISpecification, error)
+	ToOpenAPIChangeModels(changes checker.Changes) []*model.OpenAPIChange
+	ToApplicationOpenAPIVersionObj(openApiObj *obj.ApplicationOpenAPI, openApiSha string, commit *model.GitCommit, ingestedAt time.Time) *obj.ApplicationOpenAPIVersion
+	ToApplicationOpenAPIVersionModel(objVersion *obj.ApplicationOpenAPIVersion) (*model.ApplicationOpenAPIVersion, error)
+	ToApplicationOpenAPIVersionModels(objVersions []*obj.ApplicationOpenAPIVersion) ([]*model.ApplicationOpenAPIVersion, error)
+	ToOpenAPIRetentionObj(retention *model.OpenAPIRetention) *obj.ApplicationOpenAPIRetention
+	ToOpenAPIRetentionModel(objRetention *obj.ApplicationOpenAPIRetention) *model.OpenAPIRetention
 
 	ToSentinelSettingsModel(objSettings *obj.SentinelSetting) *model.SentinelSettings
+	ToModelToken(tokenObj *obj.Token) *model.Token
 
 	ToModelAppEndpointDependencies(objApplicationDependencies []*obj.ApplicationDependency) []*model.AppEndpointDependencies
+
+	ToSentinelRunModel(objRun *obj.SentinelRun) *model.SentinelRun
+	ToSentinelRunModels(objRuns []*obj.SentinelRun) []*model.SentinelRun
+	ToSentinelRunApplicationObj(result *model.ApplicationSyncResult) *obj.SentinelRunApplication
+
+	ToSyncJobModel(objJob *obj.SyncJob) *model.SyncJob
+	ToSyncJobModels(objJobs []*obj.SyncJob) []*model.SyncJob
+
+	ToContractDriftModels(objDrifts []*obj.ContractDrift) []*model.ContractDrift
+	ToProviderOperationModels(objEndpointConsumers []*obj.EndpointConsumer) []*model.ProviderOperation
 }
 
 type translator struct{}
@@ -40,6 +59,7 @@ func (t *translator) ToApplicationModel(applicationObj *obj.Application) *model.
 		Team:                  t.ToModelTeam(applicationObj.Team),
 		MonitoringInformation: t.ToModelMonitoringInformation(applicationObj),
 		Token:                 t.ToModelToken(applicationObj.Token),
+		SyncStatus:            t.ToModelSyncStatus(applicationObj),
 	}
 
 	if applicationObj.GitProvider != "" || applicationObj.GitRepositoryName != "" || applicationObj.GitRepositoryOwner != "" || applicationObj.GitRepositoryBranch != "" {
@@ -64,6 +84,22 @@ func (t *translator) ToModelTeam(teamObj *obj.Team) *model.Team {
 	}
 }
 
+func (t *translator) ToModelSyncStatus(applicationObj *obj.Application) *model.SyncStatus {
+	if applicationObj == nil {
+		return nil
+	}
+
+	return &model.SyncStatus{
+		LastAttemptAt:                applicationObj.LastSyncAttemptAt,
+		LastSuccessAt:                applicationObj.LastSyncSuccessAt,
+		LastError:                    applicationObj.LastSyncError,
+		LastErrorCategory:            applicationObj.LastSyncErrorCategory,
+		ConsecutiveFailures:          applicationObj.ConsecutiveSyncFailures,
+		ConsecutivePermanentFailures: applicationObj.ConsecutivePermanentFailures,
+		QuarantinedAt:                applicationObj.QuarantinedAt,
+	}
+}
+
 func (t *translator) ToModelMonitoringInformation(applicationObj *obj.Application) *model.MonitoringInformation {
 	if applicationObj == nil {
 		return nil
@@ -72,10 +108,13 @@ func (t *translator) ToModelMonitoringInformation(applicationObj *obj.Applicatio
 	return &model.MonitoringInformation{
 		DependenciesSha: applicationObj.DependenciesSha,
 		OpenAPISha:      applicationObj.OpenAPISha,
+		CommitSha:       applicationObj.CommitSha,
 		HasOpenApi:      applicationObj.HasOpenApi,
 		OpenApiPath:     applicationObj.OpenApiPath,
 		HasOpenClient:   applicationObj.HasOpenClient,
 		OpenClientPath:  applicationObj.OpenClientPath,
+		SyncInterval:    applicationObj.SyncInterval,
+		SyncEnabled:     !applicationObj.SyncDisabled,
 	}
 }
 
@@ -112,7 +151,9 @@ func (t *translator) toObjEndpoints(modelEndpoints model.Endpoints) obj.Endpoint
 
 		for method, details := range methods {
 			endpoints[path][method] = obj.EndpointDetails{
-				Reasons: details.Reasons,
+				Reasons:    details.Reasons,
+				Parameters: details.Parameters,
+				Fields:     details.Fields,
 			}
 		}
 	}
@@ -170,7 +211,7 @@ func (t *translator) ToApplicationsInteractionsModel(objDependencies []*obj.Appl
 	}
 }
 
-func (t *translator) ToApplicationOpenApiObj(openApiSpec *openapi3.T) (*obj.ApplicationOpenAPI, error) {
+func (t *translator) ToApplicationOpenApiObj(openApiSpec *openapi3.T, openAPIVersion string) (*obj.ApplicationOpenAPI, error) {
 	if openApiSpec == nil {
 		return nil, nil
 	}
@@ -181,7 +222,8 @@ func (t *translator) ToApplicationOpenApiObj(openApiSpec *openapi3.T) (*obj.Appl
 	}
 
 	return &obj.ApplicationOpenAPI{
-		OpenAPI: string(openApiJSON),
+		OpenAPI:        string(openApiJSON),
+		OpenAPIVersion: openAPIVersion,
 	}, nil
 }
 
@@ -201,11 +243,123 @@ func (t *translator) ToApplicationOpenApiModel(objOpenApi *obj.ApplicationOpenAP
 	}
 
 	return &model.ApplicationOpenAPISpecification{
-		Application: t.ToApplicationModel(objOpenApi.Application),
-		OpenAPISpec: openApiSpec,
+		Application:    t.ToApplicationModel(objOpenApi.Application),
+		OpenAPISpec:    openApiSpec,
+		OpenAPIVersion: objOpenApi.OpenAPIVersion,
 	}, nil
 }
 
+func (t *translator) ToOpenAPIChangeModels(changes checker.Changes) []*model.OpenAPIChange {
+	localizer := checker.NewDefaultLocalizer()
+
+	modelChanges := make([]*model.OpenAPIChange, 0, len(changes))
+	for _, change := range changes {
+		modelChanges = append(modelChanges, &model.OpenAPIChange{
+			ID:        change.GetId(),
+			Level:     change.GetLevel().String(),
+			Operation: change.GetOperation(),
+			Path:      change.GetPath(),
+			Message:   change.GetUncolorizedText(localizer),
+		})
+	}
+
+	return modelChanges
+}
+
+func (t *translator) ToApplicationOpenAPIVersionObj(openApiObj *obj.ApplicationOpenAPI, openApiSha string, commit *model.GitCommit, ingestedAt time.Time) *obj.ApplicationOpenAPIVersion {
+	if openApiObj == nil {
+		return nil
+	}
+
+	version := &obj.ApplicationOpenAPIVersion{
+		OpenAPI:        openApiObj.OpenAPI,
+		OpenAPIVersion: openApiObj.OpenAPIVersion,
+		OpenAPISha:     openApiSha,
+		IngestedAt:     ingestedAt,
+	}
+
+	if commit != nil {
+		version.CommitSha = commit.Sha
+		version.CommitMessage = commit.Message
+		version.CommitAuthorName = commit.AuthorName
+		version.CommitAuthorEmail = commit.AuthorEmail
+		version.CommittedAt = commit.Date
+	}
+
+	return version
+}
+
+func (t *translator) ToApplicationOpenAPIVersionModel(objVersion *obj.ApplicationOpenAPIVersion) (*model.ApplicationOpenAPIVersion, error) {
+	if objVersion == nil {
+		return nil, nil
+	}
+
+	var openApiSpec *openapi3.T
+	if objVersion.OpenAPI != "" {
+		var err error
+		openApiSpec, err = openapi3.NewLoader().LoadFromData([]byte(objVersion.OpenAPI))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var commit *model.GitCommit
+	if objVersion.CommitSha != "" {
+		commit = &model.GitCommit{
+			Sha:         objVersion.CommitSha,
+			Message:     objVersion.CommitMessage,
+			AuthorName:  objVersion.CommitAuthorName,
+			AuthorEmail: objVersion.CommitAuthorEmail,
+			Date:        objVersion.CommittedAt,
+		}
+	}
+
+	return &model.ApplicationOpenAPIVersion{
+		ID:             objVersion.ID,
+		Application:    t.ToApplicationModel(objVersion.Application),
+		OpenAPISha:     objVersion.OpenAPISha,
+		OpenAPIVersion: objVersion.OpenAPIVersion,
+		Commit:         commit,
+		IngestedAt:     objVersion.IngestedAt,
+		OpenAPISpec:    openApiSpec,
+	}, nil
+}
+
+func (t *translator) ToApplicationOpenAPIVersionModels(objVersions []*obj.ApplicationOpenAPIVersion) ([]*model.ApplicationOpenAPIVersion, error) {
+	versions := make([]*model.ApplicationOpenAPIVersion, 0, len(objVersions))
+	for _, objVersion := range objVersions {
+		version, err := t.ToApplicationOpenAPIVersionModel(objVersion)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (t *translator) ToOpenAPIRetentionObj(retention *model.OpenAPIRetention) *obj.ApplicationOpenAPIRetention {
+	if retention == nil {
+		return nil
+	}
+
+	return &obj.ApplicationOpenAPIRetention{
+		MaxVersions: retention.MaxVersions,
+		MaxAgeDays:  retention.MaxAgeDays,
+	}
+}
+
+func (t *translator) ToOpenAPIRetentionModel(objRetention *obj.ApplicationOpenAPIRetention) *model.OpenAPIRetention {
+	if objRetention == nil {
+		return nil
+	}
+
+	return &model.OpenAPIRetention{
+		MaxVersions: objRetention.MaxVersions,
+		MaxAgeDays:  objRetention.MaxAgeDays,
+	}
+}
+
 func (t *translator) ToSentinelSettingsModel(objSettings *obj.SentinelSetting) *model.SentinelSettings {
 	if objSettings == nil {
 		return nil
@@ -241,12 +395,16 @@ func (t *translator) ToModelAppEndpointDependencies(objApplicationDependencies [
 }
 
 func (t *translator) toModelAppEndpointDependency(objDependency *obj.ApplicationDependency) *model.AppEndpointDependencies {
-	endpointDependencies := make(map[string]bool)
+	endpointDependencies := make(map[string]model.EndpointDetails)
 
 	for endpoint, methods := range objDependency.Endpoints {
-		for method := range methods {
-			key := method + " " + endpoint
-			endpointDependencies[key] = true
+		for method, details := range methods {
+			key := model.OperationKey(method, endpoint)
+			if declaredDetails, exists := endpointDependencies[key]; exists {
+				endpointDependencies[key] = declaredDetails.Merge(model.EndpointDetails(details))
+				continue
+			}
+			endpointDependencies[key] = model.EndpointDetails(details)
 		}
 	}
 
@@ -257,3 +415,137 @@ func (t *translator) toModelAppEndpointDependency(objDependency *obj.Application
 
 	return dependency
 }
+
+func (t *translator) ToSentinelRunModel(objRun *obj.SentinelRun) *model.SentinelRun {
+	if objRun == nil {
+		return nil
+	}
+
+	applications := make([]*model.SentinelRunApplication, 0, len(objRun.Applications))
+	for _, objRunApplication := range objRun.Applications {
+		applications = append(applications, &model.SentinelRunApplication{
+			ApplicationName: objRunApplication.ApplicationName,
+			Status:          objRunApplication.Status,
+			DependenciesSha: objRunApplication.DependenciesSha,
+			OpenAPISha:      objRunApplication.OpenAPISha,
+			Duration:        time.Duration(objRunApplication.DurationMs) * time.Millisecond,
+			Error:           objRunApplication.Error,
+		})
+	}
+
+	return &model.SentinelRun{
+		ID:           objRun.ID,
+		Trigger:      objRun.Trigger,
+		StartedAt:    objRun.StartedAt,
+		FinishedAt:   objRun.FinishedAt,
+		Applications: applications,
+	}
+}
+
+func (t *translator) ToSentinelRunModels(objRuns []*obj.SentinelRun) []*model.SentinelRun {
+	runs := make([]*model.SentinelRun, 0, len(objRuns))
+	for _, objRun := range objRuns {
+		runs = append(runs, t.ToSentinelRunModel(objRun))
+	}
+	return runs
+}
+
+func (t *translator) ToSentinelRunApplicationObj(result *model.ApplicationSyncResult) *obj.SentinelRunApplication {
+	if result == nil {
+		return nil
+	}
+
+	status := model.SyncStatusSucceeded
+	if result.Failed() {
+		status = model.SyncStatusFailed
+	}
+
+	return &obj.SentinelRunApplication{
+		ApplicationName: result.Application.Name,
+		Status:          status,
+		DependenciesSha: result.DependenciesSha,
+		OpenAPISha:      result.OpenAPISha,
+		DurationMs:      result.Duration.Milliseconds(),
+		Error:           result.ErrorMessage(),
+	}
+}
+
+func (t *translator) ToSyncJobModel(objJob *obj.SyncJob) *model.SyncJob {
+	if objJob == nil {
+		return nil
+	}
+
+	var runID *uint
+	if objJob.RunID != nil {
+		id := uint(*objJob.RunID)
+		runID = &id
+	}
+
+	return &model.SyncJob{
+		ID:          objJob.ID,
+		Application: t.ToApplicationModel(objJob.Application),
+		RunID:       runID,
+		Trigger:     objJob.Trigger,
+		Status:      objJob.Status,
+		Attempts:    objJob.Attempts,
+		AvailableAt: objJob.AvailableAt,
+		ClaimedBy:   objJob.ClaimedBy,
+		StartedAt:   objJob.StartedAt,
+		FinishedAt:  objJob.FinishedAt,
+		Error:       objJob.Error,
+	}
+}
+
+func (t *translator) ToSyncJobModels(objJobs []*obj.SyncJob) []*model.SyncJob {
+	jobs := make([]*model.SyncJob, 0, len(objJobs))
+	for _, objJob := range objJobs {
+		jobs = append(jobs, t.ToSyncJobModel(objJob))
+	}
+	return jobs
+}
+
+func (t *translator) ToContractDriftModels(objDrifts []*obj.ContractDrift) []*model.ContractDrift {
+	drifts := make([]*model.ContractDrift, 0, len(objDrifts))
+	for _, objDrift := range objDrifts {
+		drift := &model.ContractDrift{
+			Path:            objDrift.Path,
+			Method:          objDrift.Method,
+			FirstDetectedAt: objDrift.FirstDetectedAt,
+		}
+		if objDrift.Dependency != nil {
+			drift.Consumer = t.ToApplicationModel(objDrift.Dependency.Consumer)
+			drift.Provider = t.ToApplicationModel(objDrift.Dependency.Provider)
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts
+}
+
+// ToProviderOperationModels groups the declared operations by method and path template, consumers naming the path
+// parameters differently call the same operation
+func (t *translator) ToProviderOperationModels(objEndpointConsumers []*obj.EndpointConsumer) []*model.ProviderOperation {
+	operations := make([]*model.ProviderOperation, 0)
+	operationsByKey := make(map[string]*model.ProviderOperation)
+
+	for _, objEndpointConsumer := range objEndpointConsumers {
+		key := model.OperationKey(objEndpointConsumer.Method, objEndpointConsumer.Path)
+
+		operation, ok := operationsByKey[key]
+		if !ok {
+			operation = &model.ProviderOperation{
+				Method:    objEndpointConsumer.Method,
+				Path:      objEndpointConsumer.Path,
+				Consumers: make([]*model.OperationConsumer, 0),
+			}
+			operationsByKey[key] = operation
+			operations = append(operations, operation)
+		}
+
+		operation.Consumers = append(operation.Consumers, &model.OperationConsumer{
+			Name:    objEndpointConsumer.ConsumerName,
+			Reasons: objEndpointConsumer.Reasons,
+		})
+	}
+
+	return operations
+}
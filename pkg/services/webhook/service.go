@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"cosmos-server/pkg/config"
+	"cosmos-server/pkg/errors"
+	"cosmos-server/pkg/log"
+	"cosmos-server/pkg/model"
+	"cosmos-server/pkg/services/application"
+	"cosmos-server/pkg/services/monitoring"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+)
+
+const (
+	GithubPushEvent = "push"
+
+	githubBranchRefPrefix = "refs/heads/"
+)
+
+//go:generate mockgen -destination=./mock/service_mock.go -package=mock cosmos-server/pkg/services/webhook Service
+
+type Service interface {
+	HandleGithubEvent(ctx context.Context, eventType, signature string, payload []byte) ([]*model.Application, error)
+}
+
+type webhookService struct {
+	config             config.WebhookConfig
+	applicationService application.Service
+	monitoringService  monitoring.Service
+	logger             log.Logger
+}
+
+func NewWebhookService(config config.WebhookConfig, applicationService application.Service, monitoringService monitoring.Service, logger log.Logger) Service {
+	return &webhookService{
+		config:             config,
+		applicationService: applicationService,
+		monitoringService:  monitoringService,
+		logger:             logger,
+	}
+}
+
+func (s *webhookService) HandleGithubEvent(ctx context.Context, eventType, signature string, payload []byte) ([]*model.Application, error) {
+	if s.config.GithubSecret == "" {
+		return nil, errors.NewForbiddenError("GitHub webhooks are not configured")
+	}
+
+	if err := github.ValidateSignature(signature, payload, []byte(s.config.GithubSecret)); err != nil {
+		return nil, errors.NewUnauthorizedError("invalid webhook signature")
+	}
+
+	if eventType != GithubPushEvent {
+		s.logger.Infof("Ignoring GitHub %s event", eventType)
+		return []*model.Application{}, nil
+	}
+
+	var pushEvent github.PushEvent
+	if err := json.Unmarshal(payload, &pushEvent); err != nil {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("invalid push event payload: %v", err))
+	}
+
+	return s.handlePushEvent(ctx, &pushEvent)
+}
+
+func (s *webhookService) handlePushEvent(ctx context.Context, pushEvent *github.PushEvent) ([]*model.Application, error) {
+	// Tag pushes and branch deletions never change the files we monitor
+	if !strings.HasPrefix(pushEvent.GetRef(), githubBranchRefPrefix) || pushEvent.GetDeleted() {
+		return []*model.Application{}, nil
+	}
+
+	branch := strings.TrimPrefix(pushEvent.GetRef(), githubBranchRefPrefix)
+	owner := pushEvent.GetRepo().GetOwner().GetLogin()
+	if owner == "" {
+		owner = pushEvent.GetRepo().GetOwner().GetName()
+	}
+	repositoryName := pushEvent.GetRepo().GetName()
+
+	applications, err := s.applicationService.GetApplicationsByRepository(ctx, model.GitProviderGithub, owner, repositoryName, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	touchedFiles := s.getTouchedFiles(pushEvent)
+
+	applicationsToSync := make([]*model.Application, 0)
+	for _, app := range applications {
+		// Pushes do not sync the applications whose sync was turned off
+		if app.MonitoringInformation != nil && !app.MonitoringInformation.SyncEnabled {
+			continue
+		}
+
+		if s.isMonitoredFileTouched(app, touchedFiles) {
+			applicationsToSync = append(applicationsToSync, app)
+		}
+	}
+
+	if len(applicationsToSync) == 0 {
+		s.logger.Infof("Push to %s/%s@%s does not touch any monitored file", owner, repositoryName, branch)
+		return applicationsToSync, nil
+	}
+
+	_, err = s.monitoringService.EnqueueApplicationsSync(ctx, model.SentinelRunTriggerWebhook, applicationsToSync)
+	if err != nil {
+		return nil, err
+	}
+
+	return applicationsToSync, nil
+}
+
+func (s *webhookService) getTouchedFiles(pushEvent *github.PushEvent) map[string]bool {
+	touchedFiles := make(map[string]bool)
+
+	commits := pushEvent.Commits
+	if pushEvent.HeadCommit != nil {
+		commits = append(commits, pushEvent.HeadCommit)
+	}
+
+	for _, commit := range commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, file := range files {
+				touchedFiles[normalizeRepositoryPath(file)] = true
+			}
+		}
+	}
+
+	return touchedFiles
+}
+
+func (s *webhookService) isMonitoredFileTouched(app *model.Application, touchedFiles map[string]bool) bool {
+	if app.MonitoringInformation == nil {
+		return false
+	}
+
+	if app.MonitoringInformation.HasOpenApi && touchedFiles[normalizeRepositoryPath(app.MonitoringInformation.OpenApiPath)] {
+		return true
+	}
+
+	if app.MonitoringInformation.HasOpenClient && touchedFiles[normalizeRepositoryPath(app.MonitoringInformation.OpenClientPath)] {
+		return true
+	}
+
+	return false
+}
+
+func normalizeRepositoryPath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
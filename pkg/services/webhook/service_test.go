@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"context"
+	"cosmos-server/pkg/config"
+	log "cosmos-server/pkg/log/mock"
+	"cosmos-server/pkg/model"
+	applicationMock "cosmos-server/pkg/services/application/mock"
+	monitoringMock "cosmos-server/pkg/services/monitoring/mock"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const mockedSecret = "webhook-secret"
+
+func TestHandleGithubEvent(t *testing.T) {
+	t.Run("handle github event - touched applications are queued", handleGithubEventQueuesTouchedApplications)
+	t.Run("handle github event - untouched applications are skipped", handleGithubEventSkipsUntouchedApplications)
+	t.Run("handle github event - applications with sync disabled are skipped", handleGithubEventSkipsSyncDisabledApplications)
+	t.Run("handle github event - invalid signature", handleGithubEventInvalidSignature)
+	t.Run("handle github event - secret not configured", handleGithubEventSecretNotConfigured)
+	t.Run("handle github event - non push event is ignored", handleGithubEventNonPushEvent)
+	t.Run("handle github event - tag push is ignored", handleGithubEventTagPush)
+}
+
+type mocks struct {
+	controller             *gomock.Controller
+	applicationServiceMock *applicationMock.MockService
+	monitoringServiceMock  *monitoringMock.MockService
+	loggerMock             *log.MockLogger
+}
+
+func setUp(t *testing.T, secret string) (Service, *mocks) {
+	controller := gomock.NewController(t)
+
+	mocks := &mocks{
+		controller:             controller,
+		applicationServiceMock: applicationMock.NewMockService(controller),
+		monitoringServiceMock:  monitoringMock.NewMockService(controller),
+		loggerMock:             log.NewMockLogger(controller),
+	}
+
+	service := NewWebhookService(config.WebhookConfig{GithubSecret: secret}, mocks.applicationServiceMock, mocks.monitoringServiceMock, mocks.loggerMock)
+
+	return service, mocks
+}
+
+func sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(mockedSecret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func getMockedPushPayload(ref string) []byte {
+	return []byte(`{
+		"ref": "` + ref + `",
+		"repository": {"name": "test-repo", "owner": {"login": "test-owner"}},
+		"commits": [{"added": [], "modified": ["docs/openapi.json", "README.md"], "removed": []}],
+		"head_commit": {"added": [], "modified": ["docs/openapi.json"], "removed": []}
+	}`)
+}
+
+func getMockedApplications() []*model.Application {
+	return []*model.Application{
+		{
+			Name: "touched-application",
+			MonitoringInformation: &model.MonitoringInformation{
+				HasOpenApi:  true,
+				OpenApiPath: "./docs/openapi.json",
+				SyncEnabled: true,
+			},
+		},
+		{
+			Name: "untouched-application",
+			MonitoringInformation: &model.MonitoringInformation{
+				HasOpenClient:  true,
+				OpenClientPath: "docs/openclient.json",
+				SyncEnabled:    true,
+			},
+		},
+	}
+}
+
+func handleGithubEventQueuesTouchedApplications(t *testing.T) {
+	service, mocks := setUp(t, mockedSecret)
+
+	payload := getMockedPushPayload("refs/heads/main")
+	applications := getMockedApplications()
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplicationsByRepository(gomock.Any(), model.GitProviderGithub, "test-owner", "test-repo", "main").
+		Return(applications, nil)
+
+	mocks.monitoringServiceMock.EXPECT().
+		EnqueueApplicationsSync(gomock.Any(), model.SentinelRunTriggerWebhook, []*model.Application{applications[0]}).
+		Return(nil, nil)
+
+	queuedApplications, err := service.HandleGithubEvent(context.TODO(), GithubPushEvent, sign(payload), payload)
+	require.NoError(t, err)
+	require.Len(t, queuedApplications, 1)
+	require.Equal(t, "touched-application", queuedApplications[0].Name)
+}
+
+func handleGithubEventSkipsUntouchedApplications(t *testing.T) {
+	service, mocks := setUp(t, mockedSecret)
+
+	payload := getMockedPushPayload("refs/heads/main")
+	applications := getMockedApplications()[1:]
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplicationsByRepository(gomock.Any(), model.GitProviderGithub, "test-owner", "test-repo", "main").
+		Return(applications, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	queuedApplications, err := service.HandleGithubEvent(context.TODO(), GithubPushEvent, sign(payload), payload)
+	require.NoError(t, err)
+	require.Empty(t, queuedApplications)
+}
+
+func handleGithubEventSkipsSyncDisabledApplications(t *testing.T) {
+	service, mocks := setUp(t, mockedSecret)
+
+	payload := getMockedPushPayload("refs/heads/main")
+	applications := getMockedApplications()[:1]
+	applications[0].MonitoringInformation.SyncEnabled = false
+
+	mocks.applicationServiceMock.EXPECT().
+		GetApplicationsByRepository(gomock.Any(), model.GitProviderGithub, "test-owner", "test-repo", "main").
+		Return(applications, nil)
+
+	mocks.loggerMock.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	queuedApplications, err := service.HandleGithubEvent(context.TODO(), GithubPushEvent, sign(payload), payload)
+	require.NoError(t, err)
+	require.Empty(t, queuedApplications)
+}
+
+func handleGithubEventInvalidSignature(t *testing.T) {
+	service, _ := setUp(t, mockedSecret)
+
+	payload := getMockedPushPayload("refs/heads/main")
+
+	_, err := service.HandleGithubEvent(context.TODO(), GithubPushEvent, "sha256=invalid", payload)
+	require.Error(t, err)
+	require.Equal(t, "invalid webhook signature", err.Error())
+}
+
+func handleGithubEventSecretNotConfigured(t *testing.T) {
+	service, _ := setUp(t, "")
+
+	payload := getMockedPushPayload("refs/heads/main")
+
+	_, err := service.HandleGithubEvent(context.TODO(), GithubPushEvent, sign(payload), payload)
+	require.Error(t, err)
+	require.Equal(t, "GitHub webhooks are not configured", err.Error())
+}
+
+func handleGithubEventNonPushEvent(t *testing.T) {
+	service, mocks := setUp(t, mockedSecret)
+
+	payload := []byte(`{"zen": "Keep it logically awesome."}`)
+
+	mocks.loggerMock.EXPECT().
+		Infof(gomock.Any(), gomock.Any())
+
+	queuedApplications, err := service.HandleGithubEvent(context.TODO(), "ping", sign(payload), payload)
+	require.NoError(t, err)
+	require.Empty(t, queuedApplications)
+}
+
+func handleGithubEventTagPush(t *testing.T) {
+	service, _ := setUp(t, mockedSecret)
+
+	payload := getMockedPushPayload("refs/tags/v1.0.0")
+
+	queuedApplications, err := service.HandleGithubEvent(context.TODO(), GithubPushEvent, sign(payload), payload)
+	require.NoError(t, err)
+	require.Empty(t, queuedApplications)
+}
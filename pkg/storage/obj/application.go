@@ -1,21 +1,33 @@
 package obj
 
+import "time"
+
 type Application struct {
 	CosmosObj
-	Name                string `gorm:"uniqueIndex"`
-	Description         string
-	TeamID              *int
-	Team                *Team `gorm:"foreignKey:TeamID"`
-	GitProvider         string
-	GitRepositoryOwner  string
-	GitRepositoryName   string
-	GitRepositoryBranch string
-	DependenciesSha     string
-	OpenAPISha          string
-	HasOpenApi          bool
-	OpenApiPath         string
-	HasOpenClient       bool
-	OpenClientPath      string
-	TokenID             *int
-	Token               *Token `gorm:"foreignKey:TokenID"`
+	Name                         string `gorm:"uniqueIndex"`
+	Description                  string
+	TeamID                       *int
+	Team                         *Team `gorm:"foreignKey:TeamID"`
+	GitProvider                  string
+	GitRepositoryOwner           string
+	GitRepositoryName            string
+	GitRepositoryBranch          string
+	DependenciesSha              string
+	OpenAPISha                   string
+	CommitSha                    string
+	HasOpenApi                   bool
+	OpenApiPath                  string
+	HasOpenClient                bool
+	OpenClientPath               string
+	SyncInterval                 *int
+	SyncDisabled                 bool
+	TokenID                      *int
+	Token                        *Token `gorm:"foreignKey:TokenID"`
+	LastSyncAttemptAt            *time.Time
+	LastSyncSuccessAt            *time.Time
+	LastSyncError                string
+	LastSyncErrorCategory        string
+	ConsecutiveSyncFailures      int
+	ConsecutivePermanentFailures int
+	QuarantinedAt                *time.Time
 }
@@ -32,7 +32,9 @@ type Endpoints map[string]EndpointMethods
 type EndpointMethods map[string]EndpointDetails
 
 type EndpointDetails struct {
-	Reasons []string `json:"reasons,omitempty"`
+	Reasons    []string `json:"reasons,omitempty"`
+	Parameters []string `json:"parameters,omitempty"`
+	Fields     []string `json:"fields,omitempty"`
 }
 
 func (e Endpoints) Value() (driver.Value, error) {
@@ -1,8 +1,34 @@
 package obj
 
+import "time"
+
 type ApplicationOpenAPI struct {
 	CosmosObj
 	ApplicationID int
 	Application   *Application `gorm:"foreignKey:ApplicationID"`
 	OpenAPI       string       `gorm:"type:jsonb"`
+	// OpenAPIVersion is the version the published document declares, like 2.0 or 3.1.0, OpenAPI is always a 3.0 document
+	OpenAPIVersion string
+}
+
+type ApplicationOpenAPIVersion struct {
+	CosmosObj
+	ApplicationID     int
+	Application       *Application `gorm:"foreignKey:ApplicationID"`
+	OpenAPI           string       `gorm:"type:jsonb"`
+	OpenAPIVersion    string
+	OpenAPISha        string
+	CommitSha         string
+	CommitMessage     string
+	CommitAuthorName  string
+	CommitAuthorEmail string
+	CommittedAt       *time.Time
+	IngestedAt        time.Time
+}
+
+type ApplicationOpenAPIRetention struct {
+	CosmosObj
+	ApplicationID int
+	MaxVersions   *int
+	MaxAgeDays    *int
 }
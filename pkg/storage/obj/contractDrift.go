@@ -0,0 +1,12 @@
+package obj
+
+import "time"
+
+type ContractDrift struct {
+	CosmosObj
+	DependencyID    int
+	Dependency      *ApplicationDependency `gorm:"foreignKey:DependencyID"`
+	Path            string
+	Method          string
+	FirstDetectedAt time.Time
+}
@@ -0,0 +1,12 @@
+package obj
+
+import "github.com/lib/pq"
+
+// EndpointConsumer is an operation a consumer declares on a provider, it is read from the endpoints of the
+// application dependencies and has no table of its own
+type EndpointConsumer struct {
+	ConsumerName string
+	Path         string
+	Method       string
+	Reasons      pq.StringArray `gorm:"type:text[]"`
+}
@@ -0,0 +1,11 @@
+package obj
+
+import "time"
+
+type SentinelLease struct {
+	Name      string `gorm:"primaryKey"`
+	Holder    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
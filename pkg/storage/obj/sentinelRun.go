@@ -0,0 +1,23 @@
+package obj
+
+import "time"
+
+type SentinelRun struct {
+	CosmosObj
+	Trigger      string
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+	Applications []*SentinelRunApplication `gorm:"foreignKey:RunID"`
+}
+
+type SentinelRunApplication struct {
+	CosmosObj
+	RunID           int
+	ApplicationID   *int
+	ApplicationName string
+	Status          string
+	DependenciesSha string
+	OpenAPISha      string
+	DurationMs      int64
+	Error           string
+}
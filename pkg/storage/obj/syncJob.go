@@ -0,0 +1,18 @@
+package obj
+
+import "time"
+
+type SyncJob struct {
+	CosmosObj
+	ApplicationID int
+	Application   *Application `gorm:"foreignKey:ApplicationID"`
+	RunID         *int
+	Trigger       string
+	Status        string
+	Attempts      int
+	AvailableAt   time.Time
+	ClaimedBy     string
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	Error         string
+}
@@ -9,10 +9,11 @@ import (
 	errorUtils "errors"
 	"fmt"
 	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresService struct {
@@ -395,6 +396,47 @@ func (s *PostgresService) GetApplicationDependenciesByProvider(ctx context.Conte
 	return dependencies, nil
 }
 
+// endpointConsumersQuery lists the operations consumers declare on a provider
+const endpointConsumersQuery = `
+SELECT consumer_name, path, method, reasons FROM (
+	SELECT
+		consumers.name AS consumer_name,
+		endpoint.key AS path,
+		UPPER(operation.key) AS method,
+		ARRAY(SELECT jsonb_array_elements_text(COALESCE(operation.value->'reasons', '[]'::jsonb))) AS reasons
+	FROM application_dependencies
+	JOIN applications AS consumers ON consumers.id = application_dependencies.consumer_id
+	CROSS JOIN LATERAL jsonb_each(application_dependencies.endpoints) AS endpoint
+	CROSS JOIN LATERAL jsonb_each(endpoint.value) AS operation
+	WHERE application_dependencies.provider_id = ?
+) AS operations`
+
+func (s *PostgresService) GetEndpointConsumers(ctx context.Context, providerName string, method string) ([]*obj.EndpointConsumer, error) {
+	provider, err := gorm.G[*obj.Application](s.db).Where("LOWER(name) = LOWER(?)", providerName).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get provider application: %v", err)
+	}
+
+	query := endpointConsumersQuery
+	args := []any{provider.ID}
+
+	if method != "" {
+		query += " WHERE method = ?"
+		args = append(args, strings.ToUpper(method))
+	}
+	query += " ORDER BY path, method, consumer_name"
+
+	var endpointConsumers []*obj.EndpointConsumer
+	if err := s.db.WithContext(ctx).Raw(query, args...).Scan(&endpointConsumers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get endpoint consumers of provider %s: %v", providerName, err)
+	}
+
+	return endpointConsumers, nil
+}
+
 func (s *PostgresService) UpdateApplicationDependencies(ctx context.Context, consumerName string, dependenciesToUpsert map[string]*obj.ApplicationDependency, pendingDependencies map[string]*obj.PendingApplicationDependency, dependenciesToDelete []*obj.ApplicationDependency, applicationDependenciesSHA string) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		consumer, err := gorm.G[*obj.Application](tx).Where("LOWER(name) = LOWER(?)", consumerName).First(ctx)
@@ -514,7 +556,7 @@ func (s *PostgresService) DeleteApplicationDependenciesTx(ctx context.Context, t
 	return nil
 }
 
-func (s *PostgresService) UpsertOpenAPISpecification(ctx context.Context, applicationName string, openAPISpec *obj.ApplicationOpenAPI, applicationOpenApiSHA string) error {
+func (s *PostgresService) UpsertOpenAPISpecification(ctx context.Context, applicationName string, openAPISpec *obj.ApplicationOpenAPI, applicationOpenApiSHA string, version *obj.ApplicationOpenAPIVersion) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		application, err := gorm.G[*obj.Application](tx).Preload("Team", nil).Where("LOWER(name) = LOWER(?)", applicationName).First(ctx)
 		if err != nil {
@@ -552,10 +594,64 @@ func (s *PostgresService) UpsertOpenAPISpecification(ctx context.Context, applic
 			return ErrNotFound
 		}
 
-		return nil
+		return s.insertOpenAPISpecificationVersionTx(ctx, tx, application, version)
 	})
 }
 
+// insertOpenAPISpecificationVersionTx skips the version when it is the same as the latest one, which happens when the
+// stored sha of the application was reset and the same specification is synced again
+func (s *PostgresService) insertOpenAPISpecificationVersionTx(ctx context.Context, tx *gorm.DB, application *obj.Application, version *obj.ApplicationOpenAPIVersion) error {
+	latestVersion, err := gorm.G[*obj.ApplicationOpenAPIVersion](tx).Where("application_id = ?", application.ID).Order("ingested_at DESC, id DESC").First(ctx)
+	if err != nil && !errorUtils.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get latest OpenAPI specification version: %v", err)
+	}
+
+	if err == nil && latestVersion.OpenAPISha == version.OpenAPISha {
+		return nil
+	}
+
+	version.ApplicationID = int(application.ID)
+	if err := gorm.G[obj.ApplicationOpenAPIVersion](tx).Create(ctx, version); err != nil {
+		return fmt.Errorf("failed to insert OpenAPI specification version: %v", err)
+	}
+
+	return s.pruneOpenAPISpecificationVersionsTx(ctx, tx, application, version.IngestedAt)
+}
+
+// pruneOpenAPISpecificationVersionsTx deletes the versions beyond the retention of the application, the latest version
+// is always kept as it is the current specification
+func (s *PostgresService) pruneOpenAPISpecificationVersionsTx(ctx context.Context, tx *gorm.DB, application *obj.Application, now time.Time) error {
+	retention, err := gorm.G[*obj.ApplicationOpenAPIRetention](tx).Where("application_id = ?", application.ID).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get OpenAPI specification retention: %v", err)
+	}
+
+	// The subquery picks the newest versions of the application, the ones a limit keeps
+	const latestVersionsQuery = "SELECT id FROM application_open_api_versions WHERE application_id = ? ORDER BY ingested_at DESC, id DESC LIMIT ?"
+
+	if retention.MaxVersions != nil {
+		err := tx.Where("application_id = ? AND id NOT IN ("+latestVersionsQuery+")", application.ID, application.ID, max(*retention.MaxVersions, 1)).
+			Delete(&obj.ApplicationOpenAPIVersion{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to prune OpenAPI specification versions of application %s: %v", application.Name, err)
+		}
+	}
+
+	if retention.MaxAgeDays != nil {
+		cutoff := now.AddDate(0, 0, -*retention.MaxAgeDays)
+		err := tx.Where("application_id = ? AND ingested_at < ? AND id NOT IN ("+latestVersionsQuery+")", application.ID, cutoff, application.ID, 1).
+			Delete(&obj.ApplicationOpenAPIVersion{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to prune OpenAPI specification versions of application %s: %v", application.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *PostgresService) CheckPendingDependenciesForApplication(ctx context.Context, applicationName string) error {
 	pendingDependencies, err := gorm.G[*obj.PendingApplicationDependency](s.db).Preload("Consumer", nil).Where("provider_name = ?", applicationName).Find(ctx)
 	if err != nil {
@@ -618,6 +714,191 @@ func (s *PostgresService) GetOpenAPISpecificationByApplicationName(ctx context.C
 	return openAPISpec, nil
 }
 
+func (s *PostgresService) GetOpenAPISpecificationVersions(ctx context.Context, applicationName string, ingestedBefore *time.Time, offset, limit int) ([]*obj.ApplicationOpenAPIVersion, int64, error) {
+	application, err := gorm.G[*obj.Application](s.db).Where("LOWER(name) = LOWER(?)", applicationName).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to get application: %v", err)
+	}
+
+	query := s.db.WithContext(ctx).Model(&obj.ApplicationOpenAPIVersion{}).Where("application_id = ?", application.ID)
+	if ingestedBefore != nil {
+		query = query.Where("ingested_at <= ?", *ingestedBefore)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count OpenAPI specification versions for application %s: %v", applicationName, err)
+	}
+
+	var versions []*obj.ApplicationOpenAPIVersion
+	err = query.Session(&gorm.Session{}).
+		Omit("open_api").
+		Order("ingested_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&versions).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get OpenAPI specification versions for application %s: %v", applicationName, err)
+	}
+
+	return versions, total, nil
+}
+
+func (s *PostgresService) GetOpenAPISpecificationVersion(ctx context.Context, applicationName string, versionID int) (*obj.ApplicationOpenAPIVersion, error) {
+	application, err := gorm.G[*obj.Application](s.db).Where("LOWER(name) = LOWER(?)", applicationName).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %v", err)
+	}
+
+	version, err := gorm.G[*obj.ApplicationOpenAPIVersion](s.db).Preload("Application", nil).Where("id = ? AND application_id = ?", versionID, application.ID).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get OpenAPI specification version %d for application %s: %v", versionID, applicationName, err)
+	}
+
+	return version, nil
+}
+
+func (s *PostgresService) GetOpenAPISpecificationRetention(ctx context.Context, applicationName string) (*obj.ApplicationOpenAPIRetention, error) {
+	application, err := gorm.G[*obj.Application](s.db).Where("LOWER(name) = LOWER(?)", applicationName).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %v", err)
+	}
+
+	retention, err := gorm.G[*obj.ApplicationOpenAPIRetention](s.db).Where("application_id = ?", application.ID).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get OpenAPI specification retention for application %s: %v", applicationName, err)
+	}
+
+	return retention, nil
+}
+
+// UpsertOpenAPISpecificationRetention stores the retention and applies it right away to the versions already stored
+func (s *PostgresService) UpsertOpenAPISpecificationRetention(ctx context.Context, applicationName string, retention *obj.ApplicationOpenAPIRetention, now time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		application, err := gorm.G[*obj.Application](tx).Where("LOWER(name) = LOWER(?)", applicationName).First(ctx)
+		if err != nil {
+			if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to get application: %v", err)
+		}
+
+		retention.ApplicationID = int(application.ID)
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "application_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"max_versions", "max_age_days", "updated_at"}),
+		}).Create(retention).Error
+		if err != nil {
+			return fmt.Errorf("failed to upsert OpenAPI specification retention: %v", err)
+		}
+
+		return s.pruneOpenAPISpecificationVersionsTx(ctx, tx, application, now)
+	})
+}
+
+func (s *PostgresService) UpdateContractDrifts(ctx context.Context, dependencyIDs []int, drifts []*obj.ContractDrift, detectedAt time.Time) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existingDrifts, err := gorm.G[*obj.ContractDrift](tx).Where("dependency_id IN ?", dependencyIDs).Find(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get contract drifts: %v", err)
+		}
+
+		stillDrifting := make(map[string]bool, len(drifts))
+		for _, drift := range drifts {
+			stillDrifting[getContractDriftKey(drift)] = true
+		}
+
+		resolvedDriftIDs := make([]uint, 0)
+		for _, existingDrift := range existingDrifts {
+			if !stillDrifting[getContractDriftKey(existingDrift)] {
+				resolvedDriftIDs = append(resolvedDriftIDs, existingDrift.ID)
+			}
+		}
+
+		if len(resolvedDriftIDs) > 0 {
+			if _, err := gorm.G[obj.ContractDrift](tx).Where("id IN ?", resolvedDriftIDs).Delete(ctx); err != nil {
+				return fmt.Errorf("failed to delete resolved contract drifts: %v", err)
+			}
+		}
+
+		if len(drifts) == 0 {
+			return nil
+		}
+
+		for _, drift := range drifts {
+			drift.FirstDetectedAt = detectedAt
+		}
+
+		// Drifts detected before keep the date they were first detected at
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "dependency_id"}, {Name: "path"}, {Name: "method"}},
+			DoNothing: true,
+		}).Create(&drifts).Error
+		if err != nil {
+			return fmt.Errorf("failed to insert contract drifts: %v", err)
+		}
+
+		return nil
+	})
+}
+
+func getContractDriftKey(drift *obj.ContractDrift) string {
+	return fmt.Sprintf("%d %s %s", drift.DependencyID, drift.Method, drift.Path)
+}
+
+func (s *PostgresService) GetContractDriftsWithApplicationInvolved(ctx context.Context, applicationName string) ([]*obj.ContractDrift, error) {
+	application, err := gorm.G[*obj.Application](s.db).Where("LOWER(name) = LOWER(?)", applicationName).First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %v", err)
+	}
+
+	drifts, err := s.getContractDriftsQuery().
+		Where("dependency_id IN (SELECT id FROM application_dependencies WHERE consumer_id = ? OR provider_id = ?)", application.ID, application.ID).
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract drifts for application %s: %v", applicationName, err)
+	}
+
+	return drifts, nil
+}
+
+func (s *PostgresService) GetAllContractDrifts(ctx context.Context) ([]*obj.ContractDrift, error) {
+	drifts, err := s.getContractDriftsQuery().Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract drifts: %v", err)
+	}
+
+	return drifts, nil
+}
+
+func (s *PostgresService) getContractDriftsQuery() gorm.ChainInterface[*obj.ContractDrift] {
+	return gorm.G[*obj.ContractDrift](s.db).
+		Preload("Dependency", nil).
+		Preload("Dependency.Consumer", nil).
+		Preload("Dependency.Consumer.Team", nil).
+		Preload("Dependency.Provider", nil).
+		Preload("Dependency.Provider.Team", nil).
+		Order("first_detected_at, id")
+}
+
 func (s *PostgresService) GetSentinelSetting(ctx context.Context, name string) (*obj.SentinelSetting, error) {
 	setting, err := gorm.G[*obj.SentinelSetting](s.db).Where("name = ?", name).First(ctx)
 	if err != nil {
@@ -656,7 +937,13 @@ func (s *PostgresService) UpdateSentinelSetting(ctx context.Context, setting *ob
 }
 
 func (s *PostgresService) GetApplicationsToMonitor(ctx context.Context) ([]*obj.Application, error) {
-	applications, err := gorm.G[*obj.Application](s.db).Where("has_open_api = ? OR has_open_client = ?", true, true).Find(ctx)
+	applications, err := gorm.G[*obj.Application](s.db).
+		Preload("Team", nil).
+		Preload("Token", nil).
+		Where("has_open_api = ? OR has_open_client = ?", true, true).
+		Where("quarantined_at IS NULL").
+		Where("sync_disabled = ?", false).
+		Find(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get applications to monitor: %v", err)
 	}
@@ -664,6 +951,412 @@ func (s *PostgresService) GetApplicationsToMonitor(ctx context.Context) ([]*obj.
 	return applications, nil
 }
 
+func (s *PostgresService) GetApplicationsByRepository(ctx context.Context, provider, owner, repositoryName, branch string) ([]*obj.Application, error) {
+	applications, err := gorm.G[*obj.Application](s.db).
+		Preload("Team", nil).
+		Preload("Token", nil).
+		Where("LOWER(git_provider) = LOWER(?) AND LOWER(git_repository_owner) = LOWER(?) AND LOWER(git_repository_name) = LOWER(?) AND git_repository_branch = ?", provider, owner, repositoryName, branch).
+		Where("has_open_api = ? OR has_open_client = ?", true, true).
+		Where("quarantined_at IS NULL").
+		Where("sync_disabled = ?", false).
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications for repository %s/%s: %v", owner, repositoryName, err)
+	}
+
+	return applications, nil
+}
+
+func (s *PostgresService) RecordApplicationSyncSuccess(ctx context.Context, applicationName string, attemptedAt time.Time, commitSha string) error {
+	result := s.db.WithContext(ctx).Model(&obj.Application{}).Where("LOWER(name) = LOWER(?)", applicationName).Updates(map[string]any{
+		"last_sync_attempt_at":           attemptedAt,
+		"last_sync_success_at":           attemptedAt,
+		"last_sync_error":                "",
+		"last_sync_error_category":       "",
+		"consecutive_sync_failures":      0,
+		"commit_sha":                     commitSha,
+		"consecutive_permanent_failures": 0,
+		"quarantined_at":                 nil,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to record sync success for application %s: %v", applicationName, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresService) RecordApplicationSyncFailure(ctx context.Context, applicationName string, attemptedAt time.Time, syncError, errorCategory string, permanent bool, quarantineThreshold int) error {
+	updates := map[string]any{
+		"last_sync_attempt_at":      attemptedAt,
+		"last_sync_error":           syncError,
+		"last_sync_error_category":  errorCategory,
+		"consecutive_sync_failures": gorm.Expr("consecutive_sync_failures + 1"),
+	}
+	if permanent {
+		updates["consecutive_permanent_failures"] = gorm.Expr("consecutive_permanent_failures + 1")
+		updates["quarantined_at"] = gorm.Expr("CASE WHEN quarantined_at IS NULL AND consecutive_permanent_failures + 1 >= ? THEN ? ELSE quarantined_at END", quarantineThreshold, attemptedAt)
+	}
+
+	result := s.db.WithContext(ctx).Model(&obj.Application{}).Where("LOWER(name) = LOWER(?)", applicationName).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to record sync failure for application %s: %v", applicationName, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresService) ReleaseApplicationQuarantine(ctx context.Context, applicationName string) error {
+	result := s.db.WithContext(ctx).Model(&obj.Application{}).Where("LOWER(name) = LOWER(?)", applicationName).Updates(map[string]any{
+		"consecutive_permanent_failures": 0,
+		"quarantined_at":                 nil,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to release quarantine of application %s: %v", applicationName, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AcquireSentinelLease takes or renews the lease if it is free, expired or already held by the holder, and reports
+// whether the holder owns it afterwards
+func (s *PostgresService) AcquireSentinelLease(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error) {
+	lease := &obj.SentinelLease{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: expiresAt,
+	}
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"holder", "expires_at", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "sentinel_leases.holder = ? OR sentinel_leases.expires_at < ?", Vars: []any{holder, now}},
+		}},
+	}).Create(lease)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acquire sentinel lease %s: %v", name, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (s *PostgresService) ReleaseSentinelLease(ctx context.Context, name, holder string) error {
+	_, err := gorm.G[obj.SentinelLease](s.db).Where("name = ? AND holder = ?", name, holder).Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to release sentinel lease %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func (s *PostgresService) InsertSentinelRun(ctx context.Context, run *obj.SentinelRun) error {
+	err := gorm.G[obj.SentinelRun](s.db).Create(ctx, run)
+	if err != nil {
+		return fmt.Errorf("failed to insert sentinel run: %v", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresService) FinishSentinelRun(ctx context.Context, runID int, finishedAt time.Time) error {
+	rowsAffected, err := gorm.G[obj.SentinelRun](s.db).Where("id = ?", runID).Update(ctx, "finished_at", finishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to finish sentinel run %d: %v", runID, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FinishCompletedSentinelRuns sets the finish time of the runs whose sync jobs are all done
+func (s *PostgresService) FinishCompletedSentinelRuns(ctx context.Context, finishedAt time.Time) error {
+	_, err := gorm.G[obj.SentinelRun](s.db).
+		Where("finished_at IS NULL").
+		Where("EXISTS (SELECT 1 FROM sync_jobs WHERE sync_jobs.run_id = sentinel_runs.id)").
+		Where("NOT EXISTS (SELECT 1 FROM sync_jobs WHERE sync_jobs.run_id = sentinel_runs.id AND sync_jobs.status IN ?)", []string{model.SyncJobStatusQueued, model.SyncJobStatusRunning}).
+		Update(ctx, "finished_at", finishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to finish completed sentinel runs: %v", err)
+	}
+
+	return nil
+}
+
+// EnqueueSyncJobs queues a sync job for every application that does not have one waiting already, and returns the
+// queued jobs of the applications, whether they were queued now or before
+func (s *PostgresService) EnqueueSyncJobs(ctx context.Context, runID int, trigger string, applicationNames []string, availableAt time.Time) ([]*obj.SyncJob, error) {
+	applications, err := gorm.G[*obj.Application](s.db).Where("name IN ?", applicationNames).Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications to sync: %v", err)
+	}
+
+	if len(applications) == 0 {
+		return []*obj.SyncJob{}, nil
+	}
+
+	applicationIDs := make([]uint, 0, len(applications))
+	jobs := make([]*obj.SyncJob, 0, len(applications))
+	for _, application := range applications {
+		applicationIDs = append(applicationIDs, application.ID)
+		jobs = append(jobs, &obj.SyncJob{
+			ApplicationID: int(application.ID),
+			RunID:         &runID,
+			Trigger:       trigger,
+			Status:        model.SyncJobStatusQueued,
+			AvailableAt:   availableAt,
+		})
+	}
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "application_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Eq{Column: clause.Column{Name: "status"}, Value: model.SyncJobStatusQueued}}},
+		DoNothing:   true,
+	}).Create(&jobs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to enqueue sync jobs: %v", result.Error)
+	}
+
+	queuedJobs, err := gorm.G[*obj.SyncJob](s.db).
+		Preload("Application", nil).
+		Where("application_id IN ? AND status = ?", applicationIDs, model.SyncJobStatusQueued).
+		Order("id").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queued sync jobs: %v", err)
+	}
+
+	return queuedJobs, nil
+}
+
+func (s *PostgresService) GetSyncJob(ctx context.Context, jobID int) (*obj.SyncJob, error) {
+	job, err := gorm.G[*obj.SyncJob](s.db).
+		Preload("Application", nil).
+		Where("id = ?", jobID).
+		First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get sync job %d: %v", jobID, err)
+	}
+
+	return job, nil
+}
+
+// ClaimSyncJob marks the oldest available job as running for the holder. Jobs locked by other workers are skipped, as
+// are jobs of applications that are being synced already
+func (s *PostgresService) ClaimSyncJob(ctx context.Context, holder string, now time.Time) (*obj.SyncJob, error) {
+	var claimedJobID uint
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var job obj.SyncJob
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND available_at <= ?", model.SyncJobStatusQueued, now).
+			Where("NOT EXISTS (SELECT 1 FROM sync_jobs running WHERE running.application_id = sync_jobs.application_id AND running.status = ?)", model.SyncJobStatusRunning).
+			Order("available_at, id").
+			Limit(1).
+			Find(&job)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		err := tx.Model(&job).Updates(map[string]any{
+			"status":     model.SyncJobStatusRunning,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"claimed_by": holder,
+			"started_at": now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		claimedJobID = job.ID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim sync job: %v", err)
+	}
+
+	if claimedJobID == 0 {
+		return nil, ErrNotFound
+	}
+
+	job, err := gorm.G[*obj.SyncJob](s.db).
+		Preload("Application", nil).
+		Preload("Application.Team", nil).
+		Preload("Application.Token", nil).
+		Where("id = ?", claimedJobID).
+		First(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claimed sync job %d: %v", claimedJobID, err)
+	}
+
+	return job, nil
+}
+
+// CompleteSyncJob only completes the job while the holder is running it, a job requeued after its worker lost it may be
+// running for another worker already
+func (s *PostgresService) CompleteSyncJob(ctx context.Context, jobID int, holder, status, syncError string, finishedAt time.Time) error {
+	// A map is used so that an empty error clears the error of a previous attempt
+	result := s.db.WithContext(ctx).Model(&obj.SyncJob{}).
+		Where("id = ? AND status = ? AND claimed_by = ?", jobID, model.SyncJobStatusRunning, holder).
+		Updates(map[string]any{
+			"status":      status,
+			"error":       syncError,
+			"finished_at": finishedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to complete sync job %d: %v", jobID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RequeueSyncJob puts a running job back in the queue. The job fails instead when it ran out of attempts or another job
+// of the application has been queued meanwhile
+func (s *PostgresService) RequeueSyncJob(ctx context.Context, jobID int, now, availableAt time.Time, maxAttempts int, syncError string) error {
+	_, err := s.requeueRunningSyncJobs(ctx, now, availableAt, maxAttempts, syncError, "id = ?", jobID)
+	if err != nil {
+		return fmt.Errorf("failed to requeue sync job %d: %v", jobID, err)
+	}
+
+	return nil
+}
+
+// RequeueStaleSyncJobs puts back in the queue the jobs left running by workers that stopped without finishing them
+func (s *PostgresService) RequeueStaleSyncJobs(ctx context.Context, startedBefore, now time.Time, maxAttempts int) (int64, error) {
+	requeued, err := s.requeueRunningSyncJobs(ctx, now, now, maxAttempts, "sync job was abandoned by its worker", "started_at < ?", startedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue stale sync jobs: %v", err)
+	}
+
+	return requeued, nil
+}
+
+func (s *PostgresService) requeueRunningSyncJobs(ctx context.Context, now, availableAt time.Time, maxAttempts int, syncError string, query string, args ...any) (int64, error) {
+	var requeued int64
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&obj.SyncJob{}).
+			Where("status = ?", model.SyncJobStatusRunning).
+			Where(query, args...).
+			Where("attempts < ?", maxAttempts).
+			Where("NOT EXISTS (SELECT 1 FROM sync_jobs queued WHERE queued.application_id = sync_jobs.application_id AND queued.status = ?)", model.SyncJobStatusQueued).
+			Updates(map[string]any{
+				"status":       model.SyncJobStatusQueued,
+				"available_at": availableAt,
+				"claimed_by":   "",
+				"started_at":   nil,
+				"error":        syncError,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		requeued = result.RowsAffected
+
+		return tx.Model(&obj.SyncJob{}).
+			Where("status = ?", model.SyncJobStatusRunning).
+			Where(query, args...).
+			Updates(map[string]any{
+				"status":      model.SyncJobStatusFailed,
+				"finished_at": now,
+				"error":       syncError,
+			}).Error
+	})
+
+	return requeued, err
+}
+
+func (s *PostgresService) GetActiveSyncJobs(ctx context.Context) ([]*obj.SyncJob, error) {
+	jobs, err := gorm.G[*obj.SyncJob](s.db).
+		Preload("Application", nil).
+		Where("status IN ?", []string{model.SyncJobStatusQueued, model.SyncJobStatusRunning}).
+		Order("available_at, id").
+		Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active sync jobs: %v", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *PostgresService) CountQueuedSyncJobs(ctx context.Context) (int64, error) {
+	count, err := gorm.G[obj.SyncJob](s.db).Where("status = ?", model.SyncJobStatusQueued).Count(ctx, "id")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count queued sync jobs: %v", err)
+	}
+
+	return count, nil
+}
+
+func (s *PostgresService) InsertSentinelRunApplication(ctx context.Context, runApplication *obj.SentinelRunApplication) error {
+	err := gorm.G[obj.SentinelRunApplication](s.db).Create(ctx, runApplication)
+	if err != nil {
+		return fmt.Errorf("failed to insert sentinel run application: %v", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresService) GetSentinelRuns(ctx context.Context, offset, limit int) ([]*obj.SentinelRun, int64, error) {
+	total, err := gorm.G[obj.SentinelRun](s.db).Where("1 = 1").Count(ctx, "id")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count sentinel runs: %v", err)
+	}
+
+	runs, err := gorm.G[*obj.SentinelRun](s.db).
+		Preload("Applications", nil).
+		Order("started_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get sentinel runs: %v", err)
+	}
+
+	return runs, total, nil
+}
+
+func (s *PostgresService) GetSentinelRun(ctx context.Context, runID int) (*obj.SentinelRun, error) {
+	run, err := gorm.G[*obj.SentinelRun](s.db).
+		Preload("Applications", func(db gorm.PreloadBuilder) error {
+			db.Order("application_name")
+			return nil
+		}).
+		Where("id = ?", runID).
+		First(ctx)
+	if err != nil {
+		if errorUtils.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get sentinel run %d: %v", runID, err)
+	}
+
+	return run, nil
+}
+
 func (s *PostgresService) InsertToken(ctx context.Context, token *obj.Token) error {
 	err := gorm.G[obj.Token](s.db).Create(ctx, token)
 	if err != nil {
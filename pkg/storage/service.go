@@ -4,6 +4,7 @@ import (
 	"context"
 	"cosmos-server/pkg/model"
 	"cosmos-server/pkg/storage/obj"
+	"time"
 )
 
 //go:generate mockgen -destination=./mock/service_mock.go -package=mock cosmos-server/pkg/storage Service
@@ -36,16 +37,50 @@ type Service interface {
 	GetApplicationDependenciesByConsumer(ctx context.Context, consumerName string) ([]*obj.ApplicationDependency, error)
 	GetApplicationDependenciesByProvider(ctx context.Context, providerName string) ([]*obj.ApplicationDependency, error)
 	GetApplicationDependenciesFromGroup(ctx context.Context, group *obj.Group) ([]*obj.ApplicationDependency, error)
+	// GetEndpointConsumers lists the operations consumers declare on the provider, all of them when method is empty
+	GetEndpointConsumers(ctx context.Context, providerName string, method string) ([]*obj.EndpointConsumer, error)
 
-	UpsertOpenAPISpecification(ctx context.Context, applicationName string, openAPISpec *obj.ApplicationOpenAPI, applicationOpenApiSHA string) error
+	// UpsertOpenAPISpecification stores openAPISpec as the current specification of the application and records it as a new version, pruning the versions the retention of the application no longer keeps
+	UpsertOpenAPISpecification(ctx context.Context, applicationName string, openAPISpec *obj.ApplicationOpenAPI, applicationOpenApiSHA string, version *obj.ApplicationOpenAPIVersion) error
 	UpdateApplicationDependencies(ctx context.Context, applicationName string, dependenciesToUpsert map[string]*obj.ApplicationDependency, pendingDependencies map[string]*obj.PendingApplicationDependency, dependenciesToDelete []*obj.ApplicationDependency, applicationDependenciesSHA string) error
 	CheckPendingDependenciesForApplication(ctx context.Context, applicationName string) error
 	GetOpenAPISpecificationByApplicationName(ctx context.Context, applicationName string) (*obj.ApplicationOpenAPI, error)
+	// GetOpenAPISpecificationVersions returns the versions ingested up to ingestedBefore, newest first and without their specification
+	GetOpenAPISpecificationVersions(ctx context.Context, applicationName string, ingestedBefore *time.Time, offset, limit int) ([]*obj.ApplicationOpenAPIVersion, int64, error)
+	GetOpenAPISpecificationVersion(ctx context.Context, applicationName string, versionID int) (*obj.ApplicationOpenAPIVersion, error)
+	GetOpenAPISpecificationRetention(ctx context.Context, applicationName string) (*obj.ApplicationOpenAPIRetention, error)
+	UpsertOpenAPISpecificationRetention(ctx context.Context, applicationName string, retention *obj.ApplicationOpenAPIRetention, now time.Time) error
+	// UpdateContractDrifts replaces the drifts of the given dependencies with drifts, the ones already stored keep the date they were first detected at
+	UpdateContractDrifts(ctx context.Context, dependencyIDs []int, drifts []*obj.ContractDrift, detectedAt time.Time) error
+	GetContractDriftsWithApplicationInvolved(ctx context.Context, applicationName string) ([]*obj.ContractDrift, error)
+	GetAllContractDrifts(ctx context.Context) ([]*obj.ContractDrift, error)
 
 	GetSentinelSetting(ctx context.Context, name string) (*obj.SentinelSetting, error)
 	InsertSentinelSetting(ctx context.Context, setting *obj.SentinelSetting) error
 	UpdateSentinelSetting(ctx context.Context, setting *obj.SentinelSetting) error
 	GetApplicationsToMonitor(ctx context.Context) ([]*obj.Application, error)
+	GetApplicationsByRepository(ctx context.Context, provider, owner, repositoryName, branch string) ([]*obj.Application, error)
+	RecordApplicationSyncSuccess(ctx context.Context, applicationName string, attemptedAt time.Time, commitSha string) error
+	RecordApplicationSyncFailure(ctx context.Context, applicationName string, attemptedAt time.Time, syncError, errorCategory string, permanent bool, quarantineThreshold int) error
+	ReleaseApplicationQuarantine(ctx context.Context, applicationName string) error
+
+	InsertSentinelRun(ctx context.Context, run *obj.SentinelRun) error
+	FinishSentinelRun(ctx context.Context, runID int, finishedAt time.Time) error
+	InsertSentinelRunApplication(ctx context.Context, runApplication *obj.SentinelRunApplication) error
+	GetSentinelRuns(ctx context.Context, offset, limit int) ([]*obj.SentinelRun, int64, error)
+	GetSentinelRun(ctx context.Context, runID int) (*obj.SentinelRun, error)
+	FinishCompletedSentinelRuns(ctx context.Context, finishedAt time.Time) error
+	EnqueueSyncJobs(ctx context.Context, runID int, trigger string, applicationNames []string, availableAt time.Time) ([]*obj.SyncJob, error)
+	GetSyncJob(ctx context.Context, jobID int) (*obj.SyncJob, error)
+	ClaimSyncJob(ctx context.Context, holder string, now time.Time) (*obj.SyncJob, error)
+	// CompleteSyncJob records the outcome of a job still running for holder, an empty syncError clears the error of a previous attempt
+	CompleteSyncJob(ctx context.Context, jobID int, holder, status, syncError string, finishedAt time.Time) error
+	RequeueSyncJob(ctx context.Context, jobID int, now, availableAt time.Time, maxAttempts int, syncError string) error
+	RequeueStaleSyncJobs(ctx context.Context, startedBefore, now time.Time, maxAttempts int) (int64, error)
+	GetActiveSyncJobs(ctx context.Context) ([]*obj.SyncJob, error)
+	CountQueuedSyncJobs(ctx context.Context) (int64, error)
+	AcquireSentinelLease(ctx context.Context, name, holder string, now, expiresAt time.Time) (bool, error)
+	ReleaseSentinelLease(ctx context.Context, name, holder string) error
 
 	InsertToken(ctx context.Context, token *obj.Token) error
 	GetTokensFromTeam(ctx context.Context, teamName string) ([]*obj.Token, error)